@@ -0,0 +1,28 @@
+-- +goose Up
+-- Tabla outbox: acciones posteriores a la creación/actualización de una solicitud
+-- que se escriben en la misma transacción y son entregadas por el worker del servicio requests
+CREATE TABLE IF NOT EXISTS outbox (
+    id bigint NOT NULL GENERATED ALWAYS AS IDENTITY (INCREMENT 1 START 1 MINVALUE 1 MAXVALUE 9223372036854775807 CACHE 1),
+    request_id bigint NOT NULL,
+    action character varying(100) NOT NULL,
+    payload jsonb NOT NULL DEFAULT '{}'::jsonb,
+    status character varying(20) NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    last_error text,
+    next_attempt_at timestamp without time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamp without time zone,
+    created_at timestamp without time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT outbox_pkey PRIMARY KEY (id),
+    CONSTRAINT outbox_request_id_fkey FOREIGN KEY (request_id)
+        REFERENCES requests(id)
+        ON UPDATE NO ACTION
+        ON DELETE CASCADE,
+    CONSTRAINT outbox_status_check CHECK (status IN ('pending', 'processing', 'done', 'dead'))
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(next_attempt_at) WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_outbox_request_id ON outbox(request_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox;
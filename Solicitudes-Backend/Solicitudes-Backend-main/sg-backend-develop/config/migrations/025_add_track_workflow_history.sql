@@ -0,0 +1,22 @@
+-- +goose Up
+-- Circuito al que corresponde cada cambio de estado: global (status_id), tasks (status_id_tasks) o property (status_id_property)
+ALTER TABLE workflow_history ADD COLUMN track character varying(20) NOT NULL DEFAULT 'global';
+
+CREATE INDEX IF NOT EXISTS idx_workflow_history_request_id_change_date ON workflow_history(request_id, change_date);
+
+-- Estados utilizados por la máquina de estados del servicio requests que no estaban cargados
+INSERT INTO request_status (id, name, description, requires_review, is_final_state, created_at)
+OVERRIDING SYSTEM VALUE
+VALUES
+(4, 'Validated', 'Request has been validated', false, true, CURRENT_TIMESTAMP),
+(5, 'Observed', 'Verification track has observations', false, false, CURRENT_TIMESTAMP),
+(7, 'Requires changes', 'Request has observations to be fixed by the citizen', false, false, CURRENT_TIMESTAMP),
+(8, 'Processing', 'Request record is being created', false, false, CURRENT_TIMESTAMP),
+(9, 'Verified', 'Request has been verified', true, false, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('request_status', 'id'), (SELECT MAX(id) FROM request_status));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_workflow_history_request_id_change_date;
+ALTER TABLE workflow_history DROP COLUMN IF EXISTS track;
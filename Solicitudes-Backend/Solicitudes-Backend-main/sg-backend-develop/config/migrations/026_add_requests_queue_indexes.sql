@@ -0,0 +1,8 @@
+-- +goose Up
+-- Índices para la paginación y los filtros de las bandejas de verificación y validación
+CREATE INDEX IF NOT EXISTS idx_requests_created_at ON requests(created_at);
+CREATE INDEX IF NOT EXISTS idx_requests_status_tracks ON requests(status_id, status_id_tasks, status_id_property);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_requests_status_tracks;
+DROP INDEX IF EXISTS idx_requests_created_at;
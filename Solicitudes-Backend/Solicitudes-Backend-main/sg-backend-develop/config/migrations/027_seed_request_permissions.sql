@@ -0,0 +1,31 @@
+-- +goose Up
+-- Permisos utilizados por las rutas protegidas del servicio requests
+INSERT INTO permissions (name, description)
+VALUES
+('request:verify', 'Verificar los circuitos de tareas y de potestad de una solicitud'),
+('request:validate', 'Validar o rechazar una solicitud verificada'),
+('request:read_all', 'Consultar las solicitudes de todos los ciudadanos')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description)
+VALUES
+('verifier', 'Personal municipal que verifica solicitudes'),
+('validator', 'Personal municipal que valida solicitudes'),
+('admin', 'Administrador del sistema')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT ro.id, pe.id
+FROM roles ro
+JOIN permissions pe ON
+    (ro.name = 'verifier' AND pe.name IN ('request:verify', 'request:read_all')) OR
+    (ro.name = 'validator' AND pe.name IN ('request:validate', 'request:read_all')) OR
+    (ro.name = 'admin' AND pe.name IN ('request:verify', 'request:validate', 'request:read_all'))
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id FROM permissions WHERE name IN ('request:verify', 'request:validate', 'request:read_all')
+);
+DELETE FROM permissions WHERE name IN ('request:verify', 'request:validate', 'request:read_all');
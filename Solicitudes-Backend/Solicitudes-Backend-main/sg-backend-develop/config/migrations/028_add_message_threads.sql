@@ -0,0 +1,34 @@
+-- +goose Up
+-- Hilo de mensajes entre el ciudadano y el personal municipal de cada solicitud
+CREATE INDEX IF NOT EXISTS idx_messages_request_id_created_at ON messages(request_id, created_at);
+
+-- Los adjuntos se guardan como documentos de la solicitud
+CREATE TABLE IF NOT EXISTS message_attachments (
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    document_id BIGINT NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    PRIMARY KEY (message_id, document_id)
+);
+
+-- Mensajes leídos por cada usuario
+CREATE TABLE IF NOT EXISTS message_reads (
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    read_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_reads_user_id ON message_reads(user_id);
+
+INSERT INTO document_types (id, name, description, is_mandatory, created_at)
+OVERRIDING SYSTEM VALUE
+VALUES
+(19, 'Adjunto de mensaje', 'Archivo adjunto a un mensaje de la solicitud', false, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('document_types', 'id'), (SELECT MAX(id) FROM document_types));
+
+-- +goose Down
+DROP TABLE IF EXISTS message_reads;
+DROP TABLE IF EXISTS message_attachments;
+DROP INDEX IF EXISTS idx_messages_request_id_created_at;
+DELETE FROM document_types WHERE id = 19 AND NOT EXISTS (SELECT 1 FROM documents WHERE document_type_id = 19);
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Definición de cada tipo de trámite: campos del formulario, documentos requeridos,
+-- circuitos de verificación y plantillas del file-manager
+ALTER TABLE request_types ADD COLUMN IF NOT EXISTS definition JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Valores de los campos del formulario que no tienen una columna propia en requests
+ALTER TABLE requests ADD COLUMN IF NOT EXISTS form_data JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+UPDATE request_types
+SET definition = '{
+    "fields": [
+        {"name": "selected_activities", "label": "Tareas a realizar", "kind": "int_list", "required": true, "min": 1},
+        {"name": "project_description", "label": "Descripción del proyecto", "kind": "text", "max_length": 5000},
+        {"name": "estimated_time", "label": "Tiempo estimado (días)", "kind": "number", "required": true, "min": 1},
+        {"name": "insurance", "label": "Requiere seguro", "kind": "boolean"},
+        {"name": "abl_debt", "label": "Deuda ABL", "kind": "text"},
+        {"name": "common_zone", "label": "Trabajos en zona común", "kind": "boolean"}
+    ],
+    "documents": [
+        {"type": 9, "label": "Reglamento de Co-propiedad o Acta de Designación", "user_types": ["Admin"]},
+        {"type": 14, "label": "Acta de Designación", "user_types": ["Admin"], "optional": true},
+        {"type": 10, "label": "Título de Propiedad o Informe de Dominio", "user_types": ["Owner", "Occupant"]},
+        {"type": 11, "label": "Autorización del Propietario", "user_types": ["Occupant"]},
+        {"type": 12, "label": "Poliza de Seguro", "required_when": "insurance"}
+    ],
+    "tracks": ["tasks", "property"],
+    "templates": ["request_start", "sworn_statement", "terms_and_conditions", "property_tax_verification", "address_document", "insurance_document"]
+}'::jsonb,
+updated_at = CURRENT_TIMESTAMP
+WHERE name = 'Aviso de Obras';
+
+-- +goose Down
+ALTER TABLE requests DROP COLUMN IF EXISTS form_data;
+ALTER TABLE request_types DROP COLUMN IF EXISTS definition;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Borradores de solicitudes: el ciudadano completa la solicitud en varias etapas y la presenta al finalizar
+INSERT INTO request_status (id, name, description, requires_review, is_final_state, created_at)
+OVERRIDING SYSTEM VALUE
+VALUES
+(10, 'Draft', 'Request saved by the citizen and not yet submitted', false, false, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('request_status', 'id'), (SELECT MAX(id) FROM request_status));
+
+-- Campos del formulario cargados hasta el momento, con los mismos nombres que el payload de alta
+ALTER TABLE requests ADD COLUMN IF NOT EXISTS draft_data JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_requests_drafts ON requests(user_id, updated_at) WHERE status_id = 10;
+
+-- Archivos del borrador, uno por tipo de documento. Se envían a file-manager al presentar la solicitud
+CREATE TABLE IF NOT EXISTS draft_documents (
+    request_id BIGINT NOT NULL REFERENCES requests(id) ON DELETE CASCADE,
+    document_type_id INT NOT NULL REFERENCES document_types(id),
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (request_id, document_type_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS draft_documents;
+DROP INDEX IF EXISTS idx_requests_drafts;
+DELETE FROM requests WHERE status_id = 10;
+ALTER TABLE requests DROP COLUMN IF EXISTS draft_data;
+DELETE FROM request_status WHERE id = 10;
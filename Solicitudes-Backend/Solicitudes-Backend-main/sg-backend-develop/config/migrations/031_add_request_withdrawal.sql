@@ -0,0 +1,22 @@
+-- +goose Up
+-- Desistimiento de la solicitud por parte del ciudadano
+INSERT INTO request_status (id, name, description, requires_review, is_final_state, created_at)
+OVERRIDING SYSTEM VALUE
+VALUES
+(11, 'Withdrawn', 'Request withdrawn by the citizen', false, true, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('request_status', 'id'), (SELECT MAX(id) FROM request_status));
+
+-- IF de desistimiento que se incorpora al expediente
+INSERT INTO document_types (id, name, description, is_mandatory, created_at)
+OVERRIDING SYSTEM VALUE
+VALUES
+(20, 'Desistimiento', 'Constancia del desistimiento de la solicitud por parte del ciudadano', false, CURRENT_TIMESTAMP)
+ON CONFLICT (id) DO NOTHING;
+
+SELECT setval(pg_get_serial_sequence('document_types', 'id'), (SELECT MAX(id) FROM document_types));
+
+-- +goose Down
+DELETE FROM document_types WHERE id = 20 AND NOT EXISTS (SELECT 1 FROM documents WHERE document_type_id = 20);
+DELETE FROM request_status WHERE id = 11 AND NOT EXISTS (SELECT 1 FROM requests WHERE status_id = 11);
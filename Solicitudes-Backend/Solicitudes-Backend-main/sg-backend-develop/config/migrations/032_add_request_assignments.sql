@@ -0,0 +1,42 @@
+-- +goose Up
+-- Permiso para reasignar solicitudes entre verificadores
+INSERT INTO permissions (name, description)
+VALUES
+('request:assign', 'Asignar o reasignar solicitudes a los verificadores')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description)
+VALUES
+('supervisor', 'Personal municipal que supervisa y distribuye la verificación de solicitudes')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT ro.id, pe.id
+FROM roles ro
+JOIN permissions pe ON
+    (ro.name = 'supervisor' AND pe.name IN ('request:verify', 'request:assign', 'request:read_all')) OR
+    (ro.name = 'admin' AND pe.name = 'request:assign')
+ON CONFLICT DO NOTHING;
+
+-- Asignación de cada circuito de verificación (tasks o property) a un verificador.
+-- La asignación vence en expires_at; una asignación vencida se considera libre
+CREATE TABLE IF NOT EXISTS request_assignments (
+    request_id BIGINT NOT NULL REFERENCES requests(id) ON DELETE CASCADE,
+    track VARCHAR(20) NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    assigned_by BIGINT NOT NULL REFERENCES users(id),
+    claimed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (request_id, track)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_assignments_user ON request_assignments(user_id, expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS request_assignments;
+
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'request:assign')
+   OR role_id IN (SELECT id FROM roles WHERE name = 'supervisor');
+DELETE FROM roles WHERE name = 'supervisor';
+DELETE FROM permissions WHERE name = 'request:assign';
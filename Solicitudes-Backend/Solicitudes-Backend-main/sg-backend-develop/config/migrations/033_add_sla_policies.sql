@@ -0,0 +1,55 @@
+-- +goose Up
+-- Plazos de respuesta, en días hábiles, de cada etapa de la solicitud. Las políticas sin tipo
+-- de trámite se aplican a los tipos que no tienen una política propia
+CREATE TABLE IF NOT EXISTS sla_policies (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    request_type_id INT REFERENCES request_types(id) ON DELETE CASCADE,
+    stage VARCHAR(30) NOT NULL CHECK (stage IN ('verification', 'validation', 'citizen_response')),
+    business_days INT NOT NULL CHECK (business_days > 0),
+    at_risk_days INT NOT NULL DEFAULT 0 CHECK (at_risk_days >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sla_policies_type_stage ON sla_policies(COALESCE(request_type_id, 0), stage);
+
+INSERT INTO sla_policies (request_type_id, stage, business_days, at_risk_days)
+VALUES
+(NULL, 'verification', 10, 2),
+(NULL, 'validation', 5, 1),
+(NULL, 'citizen_response', 15, 3)
+ON CONFLICT DO NOTHING;
+
+-- Feriados que no se cuentan como días hábiles. Se deben cargar los feriados de cada año
+CREATE TABLE IF NOT EXISTS holidays (
+    date DATE PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+INSERT INTO holidays (date, description)
+VALUES
+('2026-01-01', 'Año Nuevo'),
+('2026-02-16', 'Carnaval'),
+('2026-02-17', 'Carnaval'),
+('2026-03-24', 'Día Nacional de la Memoria por la Verdad y la Justicia'),
+('2026-04-02', 'Día del Veterano y de los Caídos en la Guerra de Malvinas'),
+('2026-04-03', 'Viernes Santo'),
+('2026-05-01', 'Día del Trabajador'),
+('2026-05-25', 'Día de la Revolución de Mayo'),
+('2026-06-15', 'Paso a la Inmortalidad del General Martín Miguel de Güemes'),
+('2026-06-20', 'Paso a la Inmortalidad del General Manuel Belgrano'),
+('2026-07-09', 'Día de la Independencia'),
+('2026-08-17', 'Paso a la Inmortalidad del General José de San Martín'),
+('2026-10-12', 'Día del Respeto a la Diversidad Cultural'),
+('2026-11-23', 'Día de la Soberanía Nacional'),
+('2026-12-08', 'Día de la Inmaculada Concepción de María'),
+('2026-12-25', 'Navidad')
+ON CONFLICT (date) DO NOTHING;
+
+-- Fechas de la etapa de cada solicitud, consultadas sobre workflow_history
+CREATE INDEX IF NOT EXISTS idx_workflow_history_request_id_track ON workflow_history(request_id, track, new_status_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_workflow_history_request_id_track;
+DROP TABLE IF EXISTS holidays;
+DROP TABLE IF EXISTS sla_policies;
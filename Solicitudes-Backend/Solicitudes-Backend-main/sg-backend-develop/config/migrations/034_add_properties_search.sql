@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm SCHEMA public;
+
+-- Normaliza un texto para las búsquedas: minúsculas, sin acentos y con las palabras separadas por un
+-- único espacio. unaccent no es IMMUTABLE, por eso se indica el diccionario para poder usarla en una
+-- columna generada
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION search_normalize(value text) RETURNS text
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE AS $$
+    SELECT trim(regexp_replace(lower(public.unaccent('public.unaccent'::regdictionary, value)), '[^a-z0-9]+', ' ', 'g'))
+$$;
+-- +goose StatementEnd
+
+-- Calle normalizada, precalculada para el autocompletado de direcciones
+ALTER TABLE properties ADD COLUMN IF NOT EXISTS street_search TEXT GENERATED ALWAYS AS (search_normalize(street)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_properties_street_search_trgm ON properties USING gin (street_search gin_trgm_ops);
+
+-- Búsqueda por prefijo de la altura y del número de ABL
+CREATE INDEX IF NOT EXISTS idx_properties_number_prefix ON properties ((number::text) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_abl_number_prefix ON abl ((abl_number::text) text_pattern_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_abl_number_prefix;
+DROP INDEX IF EXISTS idx_properties_number_prefix;
+DROP INDEX IF EXISTS idx_properties_street_search_trgm;
+ALTER TABLE properties DROP COLUMN IF EXISTS street_search;
+DROP FUNCTION IF EXISTS search_normalize(text);
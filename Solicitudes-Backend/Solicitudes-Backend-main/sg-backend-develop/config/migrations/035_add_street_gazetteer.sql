@@ -0,0 +1,125 @@
+-- +goose Up
+-- Abreviaturas usuales en los nombres de las calles y su forma expandida. Se guardan normalizadas
+-- (minúsculas, sin acentos ni puntos)
+CREATE TABLE IF NOT EXISTS street_abbreviations (
+    abbreviation VARCHAR(50) PRIMARY KEY,
+    expansion VARCHAR(255) NOT NULL
+);
+
+INSERT INTO street_abbreviations (abbreviation, expansion)
+VALUES
+('av', 'avenida'),
+('avda', 'avenida'),
+('avd', 'avenida'),
+('bv', 'boulevard'),
+('bvd', 'boulevard'),
+('bvard', 'boulevard'),
+('blvd', 'boulevard'),
+('bulevar', 'boulevard'),
+('pje', 'pasaje'),
+('psje', 'pasaje'),
+('diag', 'diagonal'),
+('cno', 'camino'),
+('pte', 'presidente'),
+('pres', 'presidente'),
+('gral', 'general'),
+('gdor', 'gobernador'),
+('int', 'intendente'),
+('dr', 'doctor'),
+('dra', 'doctora'),
+('ing', 'ingeniero'),
+('arq', 'arquitecto'),
+('prof', 'profesor'),
+('mtro', 'maestro'),
+('pbro', 'presbitero'),
+('cnel', 'coronel'),
+('tte', 'teniente'),
+('cte', 'comandante'),
+('cmte', 'comandante'),
+('sgto', 'sargento'),
+('alte', 'almirante'),
+('cap', 'capitan'),
+('sta', 'santa'),
+('sto', 'santo'),
+('hno', 'hermano'),
+('hna', 'hermana'),
+('nstra', 'nuestra'),
+('sra', 'senora')
+ON CONFLICT (abbreviation) DO NOTHING;
+
+-- Otros nombres con los que se conoce a una calle: alias o nombres anteriores a un cambio de nombre.
+-- canonical_name es el nombre vigente, como figura en properties.street
+CREATE TABLE IF NOT EXISTS street_aliases (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    canonical_name VARCHAR(255) NOT NULL,
+    alias VARCHAR(255) NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'alias' CHECK (kind IN ('alias', 'historical')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_street_aliases_alias ON street_aliases(search_normalize(alias));
+
+-- Normaliza el nombre de una calle y expande sus abreviaturas
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION street_canonical(value text) RETURNS text
+LANGUAGE sql STABLE STRICT AS $$
+    SELECT COALESCE(string_agg(COALESCE(sa.expansion, w.word), ' ' ORDER BY w.pos), '')
+    FROM regexp_split_to_table(search_normalize(value), ' ') WITH ORDINALITY AS w(word, pos)
+    LEFT JOIN street_abbreviations sa ON sa.abbreviation = w.word
+    WHERE w.word <> ''
+$$;
+-- +goose StatementEnd
+
+-- street_search pasa a guardar el nombre con las abreviaturas expandidas. Como depende de
+-- street_abbreviations deja de ser una columna generada y se mantiene con triggers
+DROP INDEX IF EXISTS idx_properties_street_search_trgm;
+ALTER TABLE properties DROP COLUMN IF EXISTS street_search;
+ALTER TABLE properties ADD COLUMN street_search TEXT;
+
+UPDATE properties SET street_search = street_canonical(street);
+
+CREATE INDEX IF NOT EXISTS idx_properties_street_search_trgm ON properties USING gin (street_search gin_trgm_ops);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION properties_street_search() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.street_search := street_canonical(NEW.street);
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_properties_street_search
+BEFORE INSERT OR UPDATE OF street ON properties
+FOR EACH ROW EXECUTE FUNCTION properties_street_search();
+
+-- Al cambiar las abreviaturas se recalculan los nombres normalizados de todas las propiedades
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION street_abbreviations_refresh() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    UPDATE properties SET street_search = street_canonical(street);
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_street_abbreviations_refresh
+AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON street_abbreviations
+FOR EACH STATEMENT EXECUTE FUNCTION street_abbreviations_refresh();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_street_abbreviations_refresh ON street_abbreviations;
+DROP FUNCTION IF EXISTS street_abbreviations_refresh();
+DROP TRIGGER IF EXISTS trg_properties_street_search ON properties;
+DROP FUNCTION IF EXISTS properties_street_search();
+
+DROP INDEX IF EXISTS idx_properties_street_search_trgm;
+ALTER TABLE properties DROP COLUMN IF EXISTS street_search;
+ALTER TABLE properties ADD COLUMN street_search TEXT GENERATED ALWAYS AS (search_normalize(street)) STORED;
+CREATE INDEX IF NOT EXISTS idx_properties_street_search_trgm ON properties USING gin (street_search gin_trgm_ops);
+
+DROP FUNCTION IF EXISTS street_canonical(text);
+DROP TABLE IF EXISTS street_aliases;
+DROP TABLE IF EXISTS street_abbreviations;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- CUIT/CUIL de los titulares del padrón de ABL, cuando se conoce. Se usa antes que el nombre para
+-- verificar la titularidad de la propiedad
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS primary_owner_cuit VARCHAR(11);
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS secondary_owner_cuit VARCHAR(11);
+
+CREATE INDEX IF NOT EXISTS idx_owners_abl_id ON owners(abl_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_owners_abl_id;
+ALTER TABLE owners DROP COLUMN IF EXISTS secondary_owner_cuit;
+ALTER TABLE owners DROP COLUMN IF EXISTS primary_owner_cuit;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Política ante solicitudes superpuestas de la misma propiedad (allow, warn o block). Los tipos de
+-- trámite sin política advierten al ciudadano
+UPDATE request_types
+SET definition = jsonb_set(definition, '{duplicate_policy}', '"warn"'),
+updated_at = CURRENT_TIMESTAMP
+WHERE name = 'Aviso de Obras';
+
+-- Búsqueda de solicitudes activas de una propiedad
+CREATE INDEX IF NOT EXISTS idx_requests_property_id_status ON requests(property_id, status_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_requests_property_id_status;
+
+UPDATE request_types
+SET definition = definition - 'duplicate_policy',
+updated_at = CURRENT_TIMESTAMP
+WHERE name = 'Aviso de Obras';
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Claves de idempotencia de los endpoints que modifican datos y la respuesta devuelta, para responder
+-- los reintentos sin volver a procesarlos. status_code es NULL mientras la solicitud se procesa
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    scope VARCHAR(500) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INT,
+    content_type VARCHAR(255),
+    body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    UNIQUE (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
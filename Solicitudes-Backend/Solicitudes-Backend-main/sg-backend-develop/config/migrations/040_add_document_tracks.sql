@@ -0,0 +1,26 @@
+-- +goose Up
+-- Circuito de verificación que decide sobre cada documento que sube el ciudadano. La póliza de seguro
+-- se verifica con las tareas y el resto de los documentos con la potestad sobre el inmueble.
+-- La decisión sobre cada documento queda en documents.is_verified y documents.observations
+UPDATE request_types
+SET definition = jsonb_set(definition, '{documents}', '[
+    {"type": 9, "label": "Reglamento de Co-propiedad o Acta de Designación", "user_types": ["Admin"], "track": "property"},
+    {"type": 14, "label": "Acta de Designación", "user_types": ["Admin"], "optional": true, "track": "property"},
+    {"type": 10, "label": "Título de Propiedad o Informe de Dominio", "user_types": ["Owner", "Occupant"], "track": "property"},
+    {"type": 11, "label": "Autorización del Propietario", "user_types": ["Occupant"], "track": "property"},
+    {"type": 12, "label": "Poliza de Seguro", "required_when": "insurance", "track": "tasks"}
+]'::jsonb),
+updated_at = CURRENT_TIMESTAMP
+WHERE name = 'Aviso de Obras';
+
+-- +goose Down
+UPDATE request_types
+SET definition = jsonb_set(definition, '{documents}', '[
+    {"type": 9, "label": "Reglamento de Co-propiedad o Acta de Designación", "user_types": ["Admin"]},
+    {"type": 14, "label": "Acta de Designación", "user_types": ["Admin"], "optional": true},
+    {"type": 10, "label": "Título de Propiedad o Informe de Dominio", "user_types": ["Owner", "Occupant"]},
+    {"type": 11, "label": "Autorización del Propietario", "user_types": ["Occupant"]},
+    {"type": 12, "label": "Poliza de Seguro", "required_when": "insurance"}
+]'::jsonb),
+updated_at = CURRENT_TIMESTAMP
+WHERE name = 'Aviso de Obras';
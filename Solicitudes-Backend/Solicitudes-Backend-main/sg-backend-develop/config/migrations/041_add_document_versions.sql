@@ -0,0 +1,20 @@
+-- +goose Up
+-- Versiones de los documentos. Al reenviar un documento del mismo tipo el file-manager marca la fila
+-- vigente como reemplazada e inserta la versión siguiente, en lugar de sobrescribirla. Cada versión
+-- conserva su número GDE y la decisión del verificador
+ALTER TABLE documents
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS superseded BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS uploaded_by VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_documents_code_type_version ON documents(code, document_type_id, version DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_documents_code_type_version;
+
+DELETE FROM documents WHERE superseded;
+
+ALTER TABLE documents
+    DROP COLUMN IF EXISTS uploaded_by,
+    DROP COLUMN IF EXISTS superseded,
+    DROP COLUMN IF EXISTS version;
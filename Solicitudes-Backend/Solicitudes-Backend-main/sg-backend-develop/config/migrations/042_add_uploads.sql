@@ -0,0 +1,20 @@
+-- +goose Up
+-- Subidas reanudables (protocolo tus). El ciudadano sube el archivo en fragmentos antes de presentar la
+-- solicitud y el alta o el reenvío refieren a la subida por su id. Los fragmentos se guardan en el
+-- almacenamiento de subidas (disco local o S3); upload_offset es la cantidad de bytes recibidos
+CREATE TABLE IF NOT EXISTS uploads (
+    id VARCHAR(32) PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document_type_id INT NOT NULL REFERENCES document_types(id),
+    filename VARCHAR(255) NOT NULL DEFAULT '',
+    upload_length BIGINT NOT NULL CHECK (upload_length > 0),
+    upload_offset BIGINT NOT NULL DEFAULT 0 CHECK (upload_offset BETWEEN 0 AND upload_length),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_expires_at ON uploads(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS uploads;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- El evento created se envía al asignar el número de expediente, por lo que ya no se envía record_assigned.
+-- Las suscripciones a record_assigned pasan a recibir created
+UPDATE webhook_subscriptions
+SET event_types = ARRAY(
+    SELECT DISTINCT unnest(array_replace(event_types, 'record_assigned', 'created'))
+), updated_at = CURRENT_TIMESTAMP
+WHERE 'record_assigned' = ANY(event_types);
+
+-- +goose Down
+-- No se puede saber qué suscripciones tenían record_assigned, quedan suscriptas a created
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Formatos aceptados para cada documento que sube el ciudadano. Los documentos sin mime_types aceptan
+-- PDF, JPEG y PNG, por lo que los tipos de trámite nuevos pueden definir sus documentos sin cambiar código
+UPDATE request_types
+SET definition = jsonb_set(definition, '{documents}', (
+    SELECT COALESCE(jsonb_agg('{"mime_types": ["application/pdf", "image/jpeg", "image/png"]}'::jsonb || doc ORDER BY position), '[]'::jsonb)
+    FROM jsonb_array_elements(definition->'documents') WITH ORDINALITY AS documents(doc, position)
+)),
+updated_at = CURRENT_TIMESTAMP
+WHERE jsonb_typeof(definition->'documents') = 'array';
+
+-- +goose Down
+UPDATE request_types
+SET definition = jsonb_set(definition, '{documents}', (
+    SELECT COALESCE(jsonb_agg(doc - 'mime_types' ORDER BY position), '[]'::jsonb)
+    FROM jsonb_array_elements(definition->'documents') WITH ORDINALITY AS documents(doc, position)
+)),
+updated_at = CURRENT_TIMESTAMP
+WHERE jsonb_typeof(definition->'documents') = 'array';
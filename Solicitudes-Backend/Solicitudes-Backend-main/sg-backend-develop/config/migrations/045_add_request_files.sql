@@ -0,0 +1,16 @@
+-- +goose Up
+-- Archivos presentados con la solicitud, hasta que el worker del outbox crea el expediente en el
+-- file-manager. Se guardan aparte para que el mensaje de outbox no copie el contenido de cada archivo
+CREATE TABLE IF NOT EXISTS request_files (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    request_id BIGINT NOT NULL REFERENCES requests(id) ON DELETE CASCADE,
+    document_type_id INT NOT NULL REFERENCES document_types(id),
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_files_request_id ON request_files(request_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS request_files;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Los estados 2 y 3 se cargaron como Approved y Rejected, pero el servicio requests usa el 2 para el error
+-- al generar el expediente y el 3 para el circuito de verificación aprobado
+UPDATE request_status
+SET name = 'Failed', description = 'Request record could not be created', requires_review = false, is_final_state = true
+WHERE id = 2;
+
+UPDATE request_status
+SET name = 'Approved', description = 'Verification track has been approved', requires_review = false, is_final_state = false
+WHERE id = 3;
+
+-- +goose Down
+UPDATE request_status
+SET name = 'Rejected', description = 'Request has been rejected', requires_review = false, is_final_state = true
+WHERE id = 3;
+
+UPDATE request_status
+SET name = 'Approved', description = 'Request has been approved', requires_review = false, is_final_state = true
+WHERE id = 2;
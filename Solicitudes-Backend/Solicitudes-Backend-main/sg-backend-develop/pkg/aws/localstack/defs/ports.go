@@ -10,6 +10,7 @@ type Stack interface {
 	Connect() error
 	GetConfig() aws.Config
 	NewSQSClient() SqsClient
+	NewS3Client() S3Client
 }
 
 type Config interface {
@@ -32,3 +33,16 @@ type SQSMessage struct {
 	ReceiptHandle string
 	Body          string
 }
+
+type S3Client interface {
+	EnsureBucket(context.Context, string) error
+	PutObject(context.Context, string, string, []byte) error
+	GetObject(context.Context, string, string) ([]byte, error)
+	ListObjects(context.Context, string, string) ([]S3Object, error)
+	DeleteObjects(context.Context, string, []string) error
+}
+
+type S3Object struct {
+	Key  string
+	Size int64
+}
@@ -0,0 +1,139 @@
+package sdkaws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/teamcubation/sg-backend/pkg/aws/localstack/defs"
+)
+
+// maxDeleteObjects es la cantidad máxima de objetos que acepta S3 en un DeleteObjects
+const maxDeleteObjects = 1000
+
+type s3Client struct {
+	config   defs.Config
+	s3Client *s3.Client
+}
+
+func (s *stack) NewS3Client() defs.S3Client {
+	return &s3Client{
+		config: s.config,
+		s3Client: s3.NewFromConfig(s.awsConfig, func(o *s3.Options) {
+			o.BaseEndpoint = aws.String(s.config.GetLocalStackEndpoint())
+			// LocalStack no resuelve los buckets como subdominios
+			o.UsePathStyle = true
+		}),
+	}
+}
+
+// EnsureBucket crea el bucket si no existe
+func (c *s3Client) EnsureBucket(ctx context.Context, bucket string) error {
+	_, err := c.s3Client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err == nil {
+		return nil
+	}
+
+	log.Printf("Bucket %s not found. Creating it now...", bucket)
+	_, err = c.s3Client.CreateBucket(ctx, &s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		var owned *types.BucketAlreadyOwnedByYou
+		if errors.As(err, &owned) {
+			return nil
+		}
+		return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+	}
+
+	return nil
+}
+
+// PutObject guarda el contenido en la clave indicada
+func (c *s3Client) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	_, err := c.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+	})
+	if err != nil {
+		return fmt.Errorf("error putting object %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// GetObject devuelve el contenido de la clave indicada
+func (c *s3Client) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	out, err := c.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting object %s: %w", key, err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading object %s: %w", key, err)
+	}
+
+	return data, nil
+}
+
+// ListObjects devuelve los objetos con el prefijo indicado, ordenados por clave
+func (c *s3Client) ListObjects(ctx context.Context, bucket, prefix string) ([]defs.S3Object, error) {
+	var objects []defs.S3Object
+
+	paginator := s3.NewListObjectsV2Paginator(c.s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing objects %s: %w", prefix, err)
+		}
+		for _, obj := range page.Contents {
+			objects = append(objects, defs.S3Object{
+				Key:  aws.ToString(obj.Key),
+				Size: aws.ToInt64(obj.Size),
+			})
+		}
+	}
+
+	return objects, nil
+}
+
+// DeleteObjects elimina las claves indicadas
+func (c *s3Client) DeleteObjects(ctx context.Context, bucket string, keys []string) error {
+	for start := 0; start < len(keys); start += maxDeleteObjects {
+		batch := keys[start:min(start+maxDeleteObjects, len(keys))]
+
+		objects := make([]types.ObjectIdentifier, len(batch))
+		for i, key := range batch {
+			objects[i] = types.ObjectIdentifier{Key: aws.String(key)}
+		}
+
+		_, err := c.s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("error deleting objects: %w", err)
+		}
+	}
+
+	return nil
+}
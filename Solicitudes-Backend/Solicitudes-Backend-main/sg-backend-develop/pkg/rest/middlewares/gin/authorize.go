@@ -0,0 +1,133 @@
+package sdkmwr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Constantes del paquete
+const (
+	// Clave de contexto del usuario autorizado
+	PrincipalContextKey = "principal"
+
+	// Claim que identifica al usuario por defecto
+	DefaultSubjectClaim = "sub"
+
+	// Mensajes de error
+	errPrincipalNotFound = "principal not found in context"
+	errForbidden         = "forbidden: missing permission"
+	errResolvePrincipal  = "unable to resolve user permissions"
+)
+
+// Principal representa al usuario autenticado junto con sus roles y permisos
+type Principal struct {
+	Subject     string
+	UserID      int64
+	Roles       []string
+	Permissions []string
+}
+
+// HasRole indica si el usuario tiene asignado el rol
+func (p *Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission indica si alguno de los roles del usuario otorga el permiso
+func (p *Principal) HasPermission(permission string) bool {
+	for _, perm := range p.Permissions {
+		if perm == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionResolver resuelve los roles y permisos del usuario identificado por el claim del token
+type PermissionResolver interface {
+	ResolvePrincipal(ctx context.Context, subject string) (*Principal, error)
+}
+
+// PermissionResolverFunc permite usar una función como PermissionResolver
+type PermissionResolverFunc func(ctx context.Context, subject string) (*Principal, error)
+
+func (f PermissionResolverFunc) ResolvePrincipal(ctx context.Context, subject string) (*Principal, error) {
+	return f(ctx, subject)
+}
+
+// AuthorizeConfig permite configurar el middleware de autorización
+type AuthorizeConfig struct {
+	Resolver     PermissionResolver
+	SubjectClaim string // Claim que identifica al usuario, por defecto "sub"
+	ContextKey   string // Clave donde el middleware JWT guardó el token
+}
+
+// Authorize middleware que resuelve los roles y permisos del usuario autenticado y los guarda en el contexto.
+// Debe registrarse después de Validate
+func Authorize(config AuthorizeConfig) gin.HandlerFunc {
+	if config.SubjectClaim == "" {
+		config.SubjectClaim = DefaultSubjectClaim
+	}
+
+	return func(c *gin.Context) {
+		subject, err := ExtractClaim(c, config.SubjectClaim, config.ContextKey)
+		if err != nil {
+			abortWithError(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		principal, err := config.Resolver.ResolvePrincipal(c.Request.Context(), subject)
+		if err != nil {
+			abortWithError(c, http.StatusForbidden, fmt.Sprintf("%s: %v", errResolvePrincipal, err))
+			return
+		}
+
+		principal.Subject = subject
+		c.Set(PrincipalContextKey, principal)
+
+		c.Next()
+	}
+}
+
+// RequirePermissions middleware que exige que el usuario tenga todos los permisos indicados
+func RequirePermissions(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := GetPrincipal(c)
+		if err != nil {
+			abortWithError(c, http.StatusForbidden, err.Error())
+			return
+		}
+
+		for _, permission := range permissions {
+			if !principal.HasPermission(permission) {
+				abortWithError(c, http.StatusForbidden, fmt.Sprintf("%s %s", errForbidden, permission))
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// GetPrincipal obtiene el usuario autorizado guardado por Authorize
+func GetPrincipal(c *gin.Context) (*Principal, error) {
+	value, exists := c.Get(PrincipalContextKey)
+	if !exists {
+		return nil, fmt.Errorf(errPrincipalNotFound)
+	}
+
+	principal, ok := value.(*Principal)
+	if !ok {
+		return nil, fmt.Errorf("invalid principal type in context")
+	}
+
+	return principal, nil
+}
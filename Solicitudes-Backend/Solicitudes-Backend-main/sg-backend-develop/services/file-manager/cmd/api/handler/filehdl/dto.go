@@ -30,6 +30,7 @@ type RequestDataDTO struct {
 	EstimatedTime      int64         `json:"time"`
 	Insurance          bool          `json:"insurance"`
 	Documents          []DocumentDTO `json:"documents" binding:"required"`
+	Templates          []string      `json:"templates"`
 }
 
 type ValidatedRequestDataDTO struct {
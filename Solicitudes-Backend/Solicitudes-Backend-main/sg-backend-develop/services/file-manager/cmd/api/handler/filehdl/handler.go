@@ -93,6 +93,7 @@ func (h *FileHandler) CreateRecord(c *gin.Context) {
 			Description: dataDTO.ProjectDesc,
 			Tasks:       dataDTO.SelectedActivities,
 			Time:        dataDTO.EstimatedTime,
+			Templates:   dataDTO.Templates,
 		}
 
 		if err := h.useCase.ProcessDocuments(ctx, recordNumber, user, memo); err != nil {
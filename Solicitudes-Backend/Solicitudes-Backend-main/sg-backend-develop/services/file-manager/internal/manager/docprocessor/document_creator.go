@@ -48,3 +48,57 @@ func ProcessDocument(ctx context.Context, user user.User, docTemplate DocumentTe
 
 	return document, nil
 }
+
+// Nombres de las plantillas que los tipos de trámite pueden declarar
+const (
+	TemplateRequestStart            = "request_start"
+	TemplateSwornStatement          = "sworn_statement"
+	TemplateTermsAndConditions      = "terms_and_conditions"
+	TemplatePropertyTaxVerification = "property_tax_verification"
+	TemplateAddressDocument         = "address_document"
+	TemplateInsuranceDocument       = "insurance_document"
+)
+
+// DefaultTemplates son las plantillas del Aviso de Obras, utilizadas cuando la solicitud no indica ninguna
+var DefaultTemplates = []string{
+	TemplateRequestStart,
+	TemplateSwornStatement,
+	TemplateTermsAndConditions,
+	TemplatePropertyTaxVerification,
+	TemplateAddressDocument,
+	TemplateInsuranceDocument,
+}
+
+// Plantillas estáticas. La del domicilio y la del seguro dependen de los documentos cargados y se generan aparte
+var staticTemplates = map[string]func() DocumentTemplate{
+	TemplateRequestStart: func() DocumentTemplate {
+		return &RequestStart{BaseDocument: BaseDocument{FilePath: "./assets/InicioSolicitud.txt"}}
+	},
+	TemplateSwornStatement: func() DocumentTemplate {
+		return &SwornStatement{BaseDocument: BaseDocument{FilePath: "./assets/DecJurada.txt"}}
+	},
+	TemplateTermsAndConditions: func() DocumentTemplate {
+		return &TermsAndConditions{BaseDocument: BaseDocument{FilePath: "./assets/TerminosYCondiciones.txt"}}
+	},
+	TemplatePropertyTaxVerification: func() DocumentTemplate {
+		return &PropertyTaxVerification{BaseDocument: BaseDocument{FilePath: "./assets/VeriFiscalInmuebleDeclaradoABL.txt"}}
+	},
+}
+
+// GetStaticTemplates devuelve las plantillas estáticas indicadas, en el mismo orden
+func GetStaticTemplates(names []string) ([]DocumentTemplate, error) {
+	var templates []DocumentTemplate
+	for _, name := range names {
+		if name == TemplateAddressDocument || name == TemplateInsuranceDocument {
+			continue
+		}
+
+		factory, ok := staticTemplates[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown document template: %s", name)
+		}
+		templates = append(templates, factory())
+	}
+
+	return templates, nil
+}
@@ -32,6 +32,7 @@ const (
 	VerificationTasks      DocumentTypeID = 16
 	VerificationProperty   DocumentTypeID = 17 // "insurance_signed"
 	Administrative         DocumentTypeID = 18
+	Withdrawal             DocumentTypeID = 20 // "withdrawal"
 )
 
 var DocumentTypeDescriptionMap = map[DocumentTypeID]string{
@@ -82,6 +83,7 @@ type SignedDocument struct {
 	SpecialNumber   string
 	Licence         string
 	Status          bool
+	UploadedBy      string // nombre de quien sube el documento, el mismo que lo firma en GDE
 }
 
 type Memo struct {
@@ -89,6 +91,7 @@ type Memo struct {
 	Description string
 	Tasks       []int
 	Time        int64
+	Templates   []string // Plantillas del tipo de trámite, vacío para las del Aviso de Obras
 }
 
 type Repository interface {
@@ -98,7 +101,7 @@ type Repository interface {
 	GetActivityNameByIDs(ctx context.Context, id []int) (string, error)
 	UpdateDocument(ctx context.Context, content string, id int) error
 	UpdateDocumentByFileID(ctx context.Context, content string, id string) error
-	UpdateDocumentByTypeID(ctx context.Context, idDoc int, doc SignedDocument) error
+	SupersedeDocument(ctx context.Context, idDoc int, doc SignedDocument) error
 	UpdateRequestStatus(ctx context.Context, id int64, status int) error
 	DeleteDocumentByID(ctx context.Context, id int) error
 }
@@ -31,7 +31,7 @@ func (r *fileRepository) GetDocumentByTypeAndCode(ctx context.Context, code stri
 	query := `
 		SELECT id, code, file_id, document_type_id, status
 		FROM documents
-		WHERE code = $1 AND document_type_id = $2
+		WHERE code = $1 AND document_type_id = $2 AND NOT superseded
 	`
 
 	doc := &documentDAO{}
@@ -57,13 +57,13 @@ func (r *fileRepository) GetDocumentByTypeAndCode(ctx context.Context, code stri
 func (r *fileRepository) SaveDocument(ctx context.Context, code string, doc SignedDocument) error {
 	query := `
 		INSERT INTO documents (
-			code, file_id, file_url, document_type_id, content, status, original_content, filename
+			code, file_id, file_url, document_type_id, content, status, original_content, filename, uploaded_by
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8
+			$1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')
 		)
 	`
 	_, err := r.db.ExecContext(ctx, query,
-		code, doc.Number, doc.URL, doc.TypeID, doc.Content, doc.Status, doc.OriginalContent, doc.Filename,
+		code, doc.Number, doc.URL, doc.TypeID, doc.Content, doc.Status, doc.OriginalContent, doc.Filename, doc.UploadedBy,
 	)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
@@ -102,25 +102,65 @@ func (r *fileRepository) UpdateDocumentByFileID(ctx context.Context, content, fi
 	return nil
 }
 
-func (r *fileRepository) UpdateDocumentByTypeID(ctx context.Context, id int, doc SignedDocument) error {
+// SupersedeDocument guarda una nueva versión del documento. La versión vigente queda marcada como
+// reemplazada, con su número GDE y la decisión del verificador, y la nueva toma el número siguiente
+func (r *fileRepository) SupersedeDocument(ctx context.Context, id int, doc SignedDocument) error {
 	query := `
-		UPDATE documents 
-		SET content = $1, file_id = $2, original_content = $3, filename = $4, updated_at = CURRENT_TIMESTAMP 
-		WHERE id = $5
+		WITH previous AS (
+			UPDATE documents
+			SET superseded = true, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND NOT superseded
+			RETURNING code, document_type_id, request_id, name, version
+		)
+		INSERT INTO documents (
+			code, document_type_id, request_id, name, version,
+			file_id, file_url, content, status, original_content, filename, uploaded_by
+		)
+		SELECT code, document_type_id, request_id, name, version + 1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')
+		FROM previous
 	`
-	_, err := r.db.ExecContext(ctx, query, doc.Content, doc.Number, doc.OriginalContent, doc.Filename, id)
+	res, err := r.db.ExecContext(ctx, query,
+		id, doc.Number, doc.URL, doc.Content, doc.Status, doc.OriginalContent, doc.Filename, doc.UploadedBy,
+	)
 	if err != nil {
 		return fmt.Errorf("error updating document: %w", err)
 	}
 
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return NotFound("document not found")
+	}
+
 	return nil
 }
 
+// UpdateRequestStatus cambia el estado de una solicitud que está procesando la generación del expediente
+// y registra el cambio en workflow_history, igual que la máquina de estados del servicio requests. El
+// cambio se notifica en el canal request_events con el mismo contenido que usa ese servicio
 func (r *fileRepository) UpdateRequestStatus(ctx context.Context, id int64, status int) error {
 	query := `
-		UPDATE requests 
-		SET status_id = $1 
-		WHERE id = $2
+		WITH previous AS (
+			SELECT id, status_id FROM requests WHERE id = $2 AND status_id = 8 FOR UPDATE
+		), updated AS (
+			UPDATE requests r
+			SET status_id = $1::int, updated_at = CURRENT_TIMESTAMP
+			FROM previous p
+			WHERE r.id = p.id
+			RETURNING r.id, r.user_id, r.file_number, p.status_id AS previous_status_id
+		), history AS (
+			INSERT INTO workflow_history (request_id, track, previous_status_id, new_status_id, change_date)
+			SELECT id, 'global', previous_status_id, $1::int, CURRENT_TIMESTAMP FROM updated
+		)
+		SELECT pg_notify('request_events', json_build_object(
+			'request_id', id,
+			'owner_id', COALESCE(user_id, 0),
+			'file_number', COALESCE(file_number, ''),
+			'track', 'global',
+			'previous_status_id', previous_status_id,
+			'new_status_id', $1::int,
+			'has_observations', false,
+			'change_date', CURRENT_TIMESTAMP
+		)::text)
+		FROM updated
 	`
 	_, err := r.db.ExecContext(ctx, query, status, id)
 	if err != nil {
@@ -170,7 +210,7 @@ func (r *fileRepository) GetDocumentsByCode(ctx context.Context, code string) ([
 	query := `
 		SELECT file_id, document_type_id
 		FROM documents
-		WHERE code = $1
+		WHERE code = $1 AND NOT superseded
 	`
 
 	rows, err := r.db.QueryContext(ctx, query, code)
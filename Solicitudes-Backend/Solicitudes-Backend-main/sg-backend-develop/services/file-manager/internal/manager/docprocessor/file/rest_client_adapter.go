@@ -158,6 +158,7 @@ func (rc *restClient) CreateGEDO(ctx context.Context, documentInfo Document) (Si
 		SpecialNumber:   response.SpecialNumber,
 		Licence:         response.Licence,
 		Status:          true,
+		UploadedBy:      documentInfo.Metadata.FullName,
 	}, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -338,6 +339,8 @@ func (a *fileUseCase) SendDocumentToRecord(ctx context.Context, code, content, r
 	return nil
 }
 
+// saveOrUpdateDocument guarda el documento firmado. Si el expediente ya tiene un documento del mismo
+// tipo se guarda como una nueva versión y la anterior queda reemplazada
 func (a *fileUseCase) saveOrUpdateDocument(ctx context.Context, code string, fileSigned file.SignedDocument) error {
 	idDoc, _, err := a.fileRepository.GetDocumentByTypeAndCode(ctx, code, int(fileSigned.TypeID))
 	if err != nil {
@@ -346,9 +349,9 @@ func (a *fileUseCase) saveOrUpdateDocument(ctx context.Context, code string, fil
 			return fmt.Errorf("error saving doc: %w", err)
 		}
 	} else {
-		err := a.fileRepository.UpdateDocumentByTypeID(ctx, idDoc, fileSigned)
+		err := a.fileRepository.SupersedeDocument(ctx, idDoc, fileSigned)
 		if err != nil {
-			return fmt.Errorf("error updating doc in repository: %w", err)
+			return fmt.Errorf("error saving new version of doc in repository: %w", err)
 		}
 	}
 
@@ -451,11 +454,14 @@ func (a *fileUseCase) generateNewDocuments(ctx context.Context, code string, use
 
 	var documents []file.Document
 
-	templates := []docprocessor.DocumentTemplate{
-		&docprocessor.RequestStart{BaseDocument: docprocessor.BaseDocument{FilePath: "./assets/InicioSolicitud.txt"}},
-		&docprocessor.SwornStatement{BaseDocument: docprocessor.BaseDocument{FilePath: "./assets/DecJurada.txt"}},
-		&docprocessor.TermsAndConditions{BaseDocument: docprocessor.BaseDocument{FilePath: "./assets/TerminosYCondiciones.txt"}},
-		&docprocessor.PropertyTaxVerification{BaseDocument: docprocessor.BaseDocument{FilePath: "./assets/VeriFiscalInmuebleDeclaradoABL.txt"}},
+	templateNames := memo.Templates
+	if len(templateNames) == 0 {
+		templateNames = docprocessor.DefaultTemplates
+	}
+
+	templates, err := docprocessor.GetStaticTemplates(templateNames)
+	if err != nil {
+		return err
 	}
 
 	for _, template := range templates {
@@ -468,6 +474,10 @@ func (a *fileUseCase) generateNewDocuments(ctx context.Context, code string, use
 		documents = append(documents, doc)
 	}
 
+	if !slices.Contains(templateNames, docprocessor.TemplateAddressDocument) {
+		return a.sendDocsToAPIAsync(ctx, code, documents)
+	}
+
 	var docTypeID []file.DocumentTypeID
 	switch userData.Type {
 	case user.Admin:
@@ -500,6 +510,10 @@ func (a *fileUseCase) generateNewDocuments(ctx context.Context, code string, use
 	}
 	documents = append(documents, addressDoc)
 
+	if !slices.Contains(templateNames, docprocessor.TemplateInsuranceDocument) {
+		return a.sendDocsToAPIAsync(ctx, code, documents)
+	}
+
 	ifNumber := ""
 	if memo.Insurance {
 		_, fileID, err := a.fileRepository.GetDocumentByTypeAndCode(ctx, code, int(file.WithInsurance))
@@ -45,3 +45,11 @@ func (ss *SmtpService) SendUpdateRequestMessage(ctx context.Context, code string
 func (ss *SmtpService) SendValidateRequestMessage(ctx context.Context, code string, data *dto.EmailData) error {
 	return ss.smtpService.SendValidateRequestMessage(ctx, code, toSdkEmailData(data))
 }
+
+func (ss *SmtpService) SendNewMessageEmail(ctx context.Context, code, content string, data *dto.EmailData) error {
+	return ss.smtpService.SendNewMessageEmail(ctx, code, content, toSdkEmailData(data))
+}
+
+func (ss *SmtpService) SendWithdrawnRequestEmail(ctx context.Context, code, reason string, data *dto.EmailData) error {
+	return ss.smtpService.SendWithdrawnRequestEmail(ctx, code, reason, toSdkEmailData(data))
+}
@@ -69,6 +69,8 @@ func (h *GinHandler) routes(secrets map[string]string) {
 	router.POST(apiBase+"/update-request", h.SendUpdateRequestMessage)
 	router.POST(apiBase+"/update-request-code", h.SendUpdateRequestByCodeMessage)
 	router.POST(apiBase+"/validate-request", h.SendValidateRequestMessage)
+	router.POST(apiBase+"/new-message", h.SendNewMessage)
+	router.POST(apiBase+"/withdrawn-request", h.SendWithdrawnRequestMessage)
 
 	// Rutas protegidas (requieren JWT válido)
 	protected := router.Group(protectedPrefix)
@@ -112,6 +114,7 @@ type emailReq struct {
 	Email        string `json:"email" binding:"required,email"`
 	Code         string `json:"code" binding:"required"`
 	Observations string `json:"observations"`
+	RequestType  string `json:"request_type"`
 }
 
 func (h *GinHandler) SendNewRequestMessage(c *gin.Context) {
@@ -268,3 +271,35 @@ func (h *GinHandler) SendValidateRequestMessage(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "new request email sent"})
 }
+
+func (h *GinHandler) SendNewMessage(c *gin.Context) {
+	var req emailReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid email format: " + err.Error()})
+		return
+	}
+
+	err := h.ucs.SendNewMessage(c.Request.Context(), req.Code, req.Email, req.Observations, req.RequestType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send new message email: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "new message email sent"})
+}
+
+func (h *GinHandler) SendWithdrawnRequestMessage(c *gin.Context) {
+	var req emailReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid email format: " + err.Error()})
+		return
+	}
+
+	err := h.ucs.SendWithdrawnRequestMessage(c.Request.Context(), req.Code, req.Email, req.Observations, req.RequestType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send withdrawn request email: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "withdrawn request email sent"})
+}
@@ -15,6 +15,8 @@ type SmtpService interface {
 	SendUpdateRequestByCodeMessage(context.Context, string, string, *dto.EmailData) error
 	SendUpdateRequestMessage(context.Context, string, *dto.EmailData) error
 	SendValidateRequestMessage(context.Context, string, *dto.EmailData) error
+	SendNewMessageEmail(context.Context, string, string, *dto.EmailData) error
+	SendWithdrawnRequestEmail(context.Context, string, string, *dto.EmailData) error
 }
 
 type UseCases interface {
@@ -23,6 +25,8 @@ type UseCases interface {
 	SendUpdateRequestByCodeMessage(context.Context, string, string, string) error
 	SendUpdateRequestMessage(context.Context, string, string) error
 	SendValidateRequestMessage(context.Context, string, string) error
+	SendNewMessage(context.Context, string, string, string, string) error
+	SendWithdrawnRequestMessage(context.Context, string, string, string, string) error
 	ActivateAccount(ctx context.Context, token string) error
 	ResendActivationEmail(ctx context.Context, token string) error
 	ResendActivationEmailExistingUser(ctx context.Context, email string) error
@@ -12,6 +12,9 @@ import (
 const subject = "Subject: Bienvenido/a solicitudes online San Isidro\r\n"
 const mime = "MIME-version: 1.0;\r\nContent-Type: text/html; charset=\"UTF-8\";\r\n\r\n"
 
+// defaultRequestType se usa en el asunto cuando no se informa el tipo de la solicitud
+const defaultRequestType = "Aviso de obra"
+
 type UseCases struct {
 	jwtService  ports.JwtService
 	smtpService ports.SmtpService
@@ -193,3 +196,36 @@ func (u *UseCases) SendValidateRequestMessage(ctx context.Context, code, email s
 	}
 	return nil
 }
+
+func (u *UseCases) SendNewMessage(ctx context.Context, code, email, content, requestType string) error {
+	data := &dto.EmailData{
+		Email:        email,
+		Subject:      fmt.Sprintf("Subject: Recibiste un nuevo mensaje en tu solicitud de %s - San Isidro\r\n", requestTypeOrDefault(requestType)),
+		BodyTemplate: mime,
+	}
+
+	if err := u.smtpService.SendNewMessageEmail(ctx, code, content, data); err != nil {
+		return fmt.Errorf("failed to send new message email: %w", err)
+	}
+	return nil
+}
+
+func (u *UseCases) SendWithdrawnRequestMessage(ctx context.Context, code, email, reason, requestType string) error {
+	data := &dto.EmailData{
+		Email:        email,
+		Subject:      fmt.Sprintf("Subject: Tu solicitud de %s fue dada de baja - San Isidro\r\n", requestTypeOrDefault(requestType)),
+		BodyTemplate: mime,
+	}
+
+	if err := u.smtpService.SendWithdrawnRequestEmail(ctx, code, reason, data); err != nil {
+		return fmt.Errorf("failed to send withdrawn request email: %w", err)
+	}
+	return nil
+}
+
+func requestTypeOrDefault(requestType string) string {
+	if requestType == "" {
+		return defaultRequestType
+	}
+	return requestType
+}
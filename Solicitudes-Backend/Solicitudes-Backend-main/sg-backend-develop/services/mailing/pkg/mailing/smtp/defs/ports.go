@@ -21,4 +21,6 @@ type Service interface {
 	SendUpdateRequestByCodeMessage(ctx context.Context, code, obs string, data *EmailData) error
 	SendUpdateRequestMessage(ctx context.Context, code string, data *EmailData) error
 	SendValidateRequestMessage(ctx context.Context, code string, data *EmailData) error
+	SendNewMessageEmail(ctx context.Context, code, content string, data *EmailData) error
+	SendWithdrawnRequestEmail(ctx context.Context, code, reason string, data *EmailData) error
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"html"
 	"net/smtp"
 	"os"
 	"strings"
@@ -275,6 +276,58 @@ func (s *service) SendValidateRequestMessage(ctx context.Context, code string, d
 	return s.sendEmail(msg, data.Email)
 }
 
+// formatMessage escapa el contenido del mensaje, escrito por el usuario, y conserva los saltos de línea
+func formatMessage(content string) string {
+	return strings.ReplaceAll(html.EscapeString(strings.TrimSpace(content)), "\n", "<br>")
+}
+
+func (s *service) SendNewMessageEmail(ctx context.Context, code, content string, data *defs.EmailData) error {
+	verificationURL := "https://sgsanisidro.gob.ar/ingresar"
+
+	htmlBody := fmt.Sprintf(`
+    <html>
+      <body style="font-family: Arial, sans-serif; margin: 0; padding: 20px; background-color: #f2f2f2;">
+        <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; border-radius: 5px; overflow: hidden; box-shadow: 0 4px 8px rgba(0,0,0,0.1);">
+          <div style="background-color: #4a5e45; padding: 20px; text-align: center;">
+            <h1 style="color: white; font-size: 24px; margin: 0;">SAN ISIDRO</h1>
+          </div>
+          <div style="padding: 20px 30px;">
+            <p style="color: #555; font-size: 18px;">
+              ¡Hola %s!
+            </p>
+            <p style="color: #555; font-size: 16px;">
+              Recibiste un nuevo mensaje en la solicitud de aviso de obra N° %s:
+            </p>
+            <p style="color: #555; font-size: 16px; font-style: italic; border-left: 4px solid #4a5e45; padding-left: 12px;">
+              %s
+            </p>
+            <p style="color: #555; font-size: 16px;">
+              Podés responderlo y ver los archivos adjuntos entrando a tu cuenta:
+            </p>
+            <div style="text-align: center; margin: 20px 0;">
+              <a href="%s"
+                 style="background-color: #4a5e45; color: white; padding: 15px 25px;
+                        text-decoration: none; border-radius: 5px; font-size: 16px;
+                        display: inline-block;">
+                Ver mensajes
+              </a>
+            </div>
+            <p style="color: #555; font-size: 16px;">
+              ¡Muchas gracias!<br>
+              Saludos,<br>
+              El equipo de San Isidro
+            </p>
+          </div>
+        </div>
+      </body>
+    </html>
+    `, data.Name, code, formatMessage(content), verificationURL)
+
+	msg := []byte(fmt.Sprintf("%s%s%s", data.Subject, data.BodyTemplate, htmlBody))
+
+	return s.sendEmail(msg, data.Email)
+}
+
 func (s *service) sendEmail(msg []byte, email string) error {
 	host := s.config.GetSMTPServer()
 	port := s.config.GetPort()
@@ -367,3 +420,56 @@ func (s *service) sendEmail(msg []byte, email string) error {
 
 	return nil
 }
+
+func (s *service) SendWithdrawnRequestEmail(ctx context.Context, code, reason string, data *defs.EmailData) error {
+	verificationURL := "https://sgsanisidro.gob.ar/ingresar"
+
+	htmlBody := fmt.Sprintf(`
+    <html>
+      <body style="font-family: Arial, sans-serif; margin: 0; padding: 20px; background-color: #f2f2f2;">
+        <div style="max-width: 600px; margin: 0 auto; background-color: #ffffff; border-radius: 5px; overflow: hidden; box-shadow: 0 4px 8px rgba(0,0,0,0.1);">
+          <div style="background-color: #4a5e45; padding: 20px; text-align: center;">
+            <h1 style="color: white; font-size: 24px; margin: 0;">SAN ISIDRO</h1>
+          </div>
+          <div style="padding: 20px 30px;">
+            <p style="color: #555; font-size: 18px;">
+              ¡Hola %s!
+            </p>
+            <p style="color: #555; font-size: 16px;">
+              Registramos el desistimiento de tu solicitud de aviso de obra N° %s. El trámite quedó dado de baja
+              y la decisión se incorporó al expediente.
+            </p>
+            <p style="color: #555; font-size: 16px; font-style: italic; border-left: 4px solid #4a5e45; padding-left: 12px;">
+              %s
+            </p>
+            <p style="color: #555; font-size: 16px;">
+              Si fue un error, podés iniciar una nueva solicitud entrando a tu cuenta:
+            </p>
+            <div style="text-align: center; margin: 20px 0;">
+              <a href="%s"
+                 style="background-color: #4a5e45; color: white; padding: 15px 25px;
+                        text-decoration: none; border-radius: 5px; font-size: 16px;
+                        display: inline-block;">
+                Ingresar a mi cuenta
+              </a>
+            </div>
+            <p style="color: #555; font-size: 16px;">
+              Si tenés alguna consulta, no dudes en escribirnos a 
+              <a href="mailto:mesadigital@sanisidro.gob.ar" style="color: #4a5e45;">
+                mesadigital@sanisidro.gob.ar
+              </a>.
+            </p>
+            <p style="color: #555; font-size: 16px;">
+              Saludos,<br>
+              El equipo de San Isidro
+            </p>
+          </div>
+        </div>
+      </body>
+    </html>
+    `, data.Name, code, formatMessage(reason), verificationURL)
+
+	msg := []byte(fmt.Sprintf("%s%s%s", data.Subject, data.BodyTemplate, htmlBody))
+
+	return s.sendEmail(msg, data.Email)
+}
@@ -9,6 +9,8 @@ import (
 	reqinb "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/inbound"
 	reqout "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound"
 	req "github.com/teamcubation/sg-backend/services/requests/internal/request/core"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	ports "github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
 )
 
 func init() {
@@ -29,7 +31,68 @@ func main() {
 		log.Fatalf("Http Client error: %v", err)
 	}
 
-	reqUsecases := req.NewUseCases(repository, httpClient)
+	idempotencyCfg := config.GetIdempotencyConfig()
+	idempotencyOptions := domain.IdempotencyOptions{
+		TTL:             idempotencyCfg.TTL,
+		CleanupInterval: idempotencyCfg.CleanupInterval,
+	}
+
+	uploadCfg := config.GetUploadConfig()
+	uploadOptions := domain.UploadOptions{
+		MaxFileSize:     uploadCfg.MaxFileSize,
+		MaxRequestSize:  uploadCfg.MaxRequestSize,
+		TTL:             uploadCfg.TTL,
+		CleanupInterval: uploadCfg.CleanupInterval,
+	}
+
+	uploadStore, err := newUploadStore(ctx, uploadCfg)
+	if err != nil {
+		log.Fatalf("Upload store error: %v", err)
+	}
+
+	requestEvents := req.NewRequestEvents(repository)
+	go requestEvents.Start(ctx)
+
+	reqUsecases := req.NewUseCases(repository, httpClient, requestEvents, uploadStore, domain.AssignmentOptions{
+		Lease: config.GetAssignmentConfig().Lease,
+	}, idempotencyOptions, uploadOptions)
+
+	outboxCfg := config.GetOutboxConfig()
+	outboxWorker := req.NewOutboxWorker(repository, httpClient, domain.OutboxOptions{
+		PollInterval: outboxCfg.PollInterval,
+		BatchSize:    outboxCfg.BatchSize,
+		MaxAttempts:  outboxCfg.MaxAttempts,
+		BaseBackoff:  outboxCfg.BaseBackoff,
+		MaxBackoff:   outboxCfg.MaxBackoff,
+		Lease:        outboxCfg.Lease,
+		RateLimit:    outboxCfg.RateLimit,
+	})
+	go outboxWorker.Start(ctx)
+
+	draftCfg := config.GetDraftConfig()
+	draftCleaner := req.NewDraftCleaner(repository, domain.DraftOptions{
+		TTL:             draftCfg.TTL,
+		CleanupInterval: draftCfg.CleanupInterval,
+	})
+	go draftCleaner.Start(ctx)
+
+	idempotencyCleaner := req.NewIdempotencyCleaner(repository, idempotencyOptions)
+	go idempotencyCleaner.Start(ctx)
+
+	uploadCleaner := req.NewUploadCleaner(repository, uploadStore, uploadOptions)
+	go uploadCleaner.Start(ctx)
+
+	webhookCfg := config.GetWebhookConfig()
+	webhookWorker := req.NewWebhookWorker(repository, httpClient, domain.WebhookOptions{
+		PollInterval: webhookCfg.PollInterval,
+		BatchSize:    webhookCfg.BatchSize,
+		MaxAttempts:  webhookCfg.MaxAttempts,
+		BaseBackoff:  webhookCfg.BaseBackoff,
+		MaxBackoff:   webhookCfg.MaxBackoff,
+		Lease:        webhookCfg.Lease,
+		Timeout:      webhookCfg.Timeout,
+	})
+	go webhookWorker.Start(ctx)
 
 	reqHandler, err := reqinb.NewGinHandler(reqUsecases)
 	if err != nil {
@@ -41,3 +104,11 @@ func main() {
 		log.Fatalf("Gin Server error at start: %v", err)
 	}
 }
+
+// newUploadStore crea el almacenamiento de las subidas reanudables configurado
+func newUploadStore(ctx context.Context, cfg config.UploadConfig) (ports.UploadStore, error) {
+	if cfg.Storage == config.UploadStorageS3 {
+		return reqout.NewS3UploadStore(ctx, cfg.Bucket)
+	}
+	return reqout.NewLocalUploadStore(cfg.Dir)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	sdkclo "github.com/teamcubation/sg-backend/pkg/config/config-loader"
@@ -25,14 +27,57 @@ const (
 	DefaultTokenDuration  = 24 * time.Hour
 	DefaultMaxRetries     = 3
 	DefaultTimeoutSeconds = 30
+
+	// Outbox defaults
+	DefaultOutboxPollInterval = 5 * time.Second
+	DefaultOutboxBatchSize    = 20
+	DefaultOutboxMaxAttempts  = 10
+	DefaultOutboxBaseBackoff  = 10 * time.Second
+	DefaultOutboxMaxBackoff   = 1 * time.Hour
+	DefaultOutboxLease        = 2 * time.Minute
+	DefaultOutboxRateLimit    = 5
+
+	// Draft defaults
+	DefaultDraftTTL             = 30 * 24 * time.Hour
+	DefaultDraftCleanupInterval = 1 * time.Hour
+
+	// Assignment defaults
+	DefaultAssignmentLease = 30 * time.Minute
+
+	// Idempotency defaults
+	DefaultIdempotencyTTL             = 24 * time.Hour
+	DefaultIdempotencyCleanupInterval = 1 * time.Hour
+
+	// Upload defaults
+	DefaultUploadMaxFileSize     = 10 << 20
+	DefaultUploadMaxRequestSize  = 25 << 20
+	DefaultUploadTTL             = 24 * time.Hour
+	DefaultUploadCleanupInterval = 1 * time.Hour
+	DefaultUploadStorage         = UploadStorageLocal
+	DefaultUploadBucket          = "sg-uploads"
+
+	// Webhook defaults
+	DefaultWebhookPollInterval = 5 * time.Second
+	DefaultWebhookBatchSize    = 20
+	DefaultWebhookMaxAttempts  = 8
+	DefaultWebhookBaseBackoff  = 30 * time.Second
+	DefaultWebhookMaxBackoff   = 6 * time.Hour
+	DefaultWebhookLease        = 1 * time.Minute
+	DefaultWebhookTimeout      = 10 * time.Second
 )
 
 // Config estructura principal de configuración
 type Config struct {
-	App        AppConfig
-	Auth       AuthConfig
-	Middleware MiddlewareConfig
-	External   ExternalServicesConfig
+	App         AppConfig
+	Auth        AuthConfig
+	Middleware  MiddlewareConfig
+	External    ExternalServicesConfig
+	Outbox      OutboxConfig
+	Drafts      DraftConfig
+	Assignment  AssignmentConfig
+	Idempotency IdempotencyConfig
+	Uploads     UploadConfig
+	Webhooks    WebhookConfig
 }
 
 // AppConfig configuración general de la aplicación
@@ -49,6 +94,63 @@ type AuthConfig struct {
 	ContextKey    string
 }
 
+// OutboxConfig configuración del worker de outbox
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+	RateLimit    int // Entregas por segundo como máximo
+}
+
+// DraftConfig configuración de la limpieza de borradores
+type DraftConfig struct {
+	TTL             time.Duration // Tiempo sin modificaciones tras el cual se elimina un borrador
+	CleanupInterval time.Duration
+}
+
+// AssignmentConfig configuración de la asignación de solicitudes a verificadores
+type AssignmentConfig struct {
+	Lease time.Duration // Duración de la asignación tomada por un verificador
+}
+
+// IdempotencyConfig configuración de las claves de idempotencia
+type IdempotencyConfig struct {
+	TTL             time.Duration // Tiempo durante el que se responden los reintentos con la respuesta guardada
+	CleanupInterval time.Duration
+}
+
+// Almacenamientos de las subidas reanudables
+const (
+	UploadStorageLocal = "local"
+	UploadStorageS3    = "s3"
+)
+
+// UploadConfig límites de los archivos que sube el ciudadano, en bytes una vez decodificados, y
+// almacenamiento de las subidas reanudables
+type UploadConfig struct {
+	MaxFileSize     int64
+	MaxRequestSize  int64         // Suma de los archivos de una misma solicitud
+	TTL             time.Duration // Tiempo para completar la subida y usarla en una solicitud
+	CleanupInterval time.Duration
+	Storage         string // local o s3 (LocalStack)
+	Dir             string // Directorio del almacenamiento local
+	Bucket          string // Bucket del almacenamiento S3
+}
+
+// WebhookConfig configuración del worker de webhooks
+type WebhookConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+	Timeout      time.Duration // Tiempo máximo de espera de la respuesta del suscriptor
+}
+
 // MiddlewareConfig configuración de middlewares
 type MiddlewareConfig struct {
 	Auth sdkmwr.Config
@@ -111,6 +213,44 @@ func Load() error {
 				BaseURL:      os.Getenv("MIARG_BASE_URL"),
 			},
 		},
+		Outbox: OutboxConfig{
+			PollInterval: getEnvSeconds("OUTBOX_POLL_INTERVAL_SECONDS"),
+			BatchSize:    getEnvInt("OUTBOX_BATCH_SIZE"),
+			MaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS"),
+			BaseBackoff:  getEnvSeconds("OUTBOX_BASE_BACKOFF_SECONDS"),
+			MaxBackoff:   getEnvSeconds("OUTBOX_MAX_BACKOFF_SECONDS"),
+			Lease:        getEnvSeconds("OUTBOX_LEASE_SECONDS"),
+			RateLimit:    getEnvInt("OUTBOX_RATE_LIMIT_PER_SECOND"),
+		},
+		Drafts: DraftConfig{
+			TTL:             time.Duration(getEnvInt("DRAFT_TTL_DAYS")) * 24 * time.Hour,
+			CleanupInterval: time.Duration(getEnvInt("DRAFT_CLEANUP_INTERVAL_MINUTES")) * time.Minute,
+		},
+		Assignment: AssignmentConfig{
+			Lease: time.Duration(getEnvInt("ASSIGNMENT_LEASE_MINUTES")) * time.Minute,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:             time.Duration(getEnvInt("IDEMPOTENCY_TTL_HOURS")) * time.Hour,
+			CleanupInterval: time.Duration(getEnvInt("IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES")) * time.Minute,
+		},
+		Uploads: UploadConfig{
+			MaxFileSize:     int64(getEnvInt("UPLOAD_MAX_FILE_SIZE_MB")) << 20,
+			MaxRequestSize:  int64(getEnvInt("UPLOAD_MAX_REQUEST_SIZE_MB")) << 20,
+			TTL:             time.Duration(getEnvInt("UPLOAD_TTL_HOURS")) * time.Hour,
+			CleanupInterval: time.Duration(getEnvInt("UPLOAD_CLEANUP_INTERVAL_MINUTES")) * time.Minute,
+			Storage:         os.Getenv("UPLOAD_STORAGE"),
+			Dir:             os.Getenv("UPLOAD_STORAGE_DIR"),
+			Bucket:          os.Getenv("UPLOAD_S3_BUCKET"),
+		},
+		Webhooks: WebhookConfig{
+			PollInterval: getEnvSeconds("WEBHOOK_POLL_INTERVAL_SECONDS"),
+			BatchSize:    getEnvInt("WEBHOOK_BATCH_SIZE"),
+			MaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS"),
+			BaseBackoff:  getEnvSeconds("WEBHOOK_BASE_BACKOFF_SECONDS"),
+			MaxBackoff:   getEnvSeconds("WEBHOOK_MAX_BACKOFF_SECONDS"),
+			Lease:        getEnvSeconds("WEBHOOK_LEASE_SECONDS"),
+			Timeout:      getEnvSeconds("WEBHOOK_TIMEOUT_SECONDS"),
+		},
 	}
 
 	// Establecer valores por defecto si no están configurados
@@ -138,6 +278,98 @@ func setDefaults() {
 	if cfg.Auth.ContextKey == "" {
 		cfg.Auth.ContextKey = DefaultContextKey
 	}
+	if cfg.Outbox.PollInterval == 0 {
+		cfg.Outbox.PollInterval = DefaultOutboxPollInterval
+	}
+	if cfg.Outbox.BatchSize == 0 {
+		cfg.Outbox.BatchSize = DefaultOutboxBatchSize
+	}
+	if cfg.Outbox.MaxAttempts == 0 {
+		cfg.Outbox.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+	if cfg.Outbox.BaseBackoff == 0 {
+		cfg.Outbox.BaseBackoff = DefaultOutboxBaseBackoff
+	}
+	if cfg.Outbox.MaxBackoff == 0 {
+		cfg.Outbox.MaxBackoff = DefaultOutboxMaxBackoff
+	}
+	if cfg.Outbox.Lease == 0 {
+		cfg.Outbox.Lease = DefaultOutboxLease
+	}
+	if cfg.Outbox.RateLimit == 0 {
+		cfg.Outbox.RateLimit = DefaultOutboxRateLimit
+	}
+	if cfg.Drafts.TTL == 0 {
+		cfg.Drafts.TTL = DefaultDraftTTL
+	}
+	if cfg.Drafts.CleanupInterval == 0 {
+		cfg.Drafts.CleanupInterval = DefaultDraftCleanupInterval
+	}
+	if cfg.Assignment.Lease == 0 {
+		cfg.Assignment.Lease = DefaultAssignmentLease
+	}
+	if cfg.Idempotency.TTL == 0 {
+		cfg.Idempotency.TTL = DefaultIdempotencyTTL
+	}
+	if cfg.Idempotency.CleanupInterval == 0 {
+		cfg.Idempotency.CleanupInterval = DefaultIdempotencyCleanupInterval
+	}
+	if cfg.Uploads.MaxFileSize == 0 {
+		cfg.Uploads.MaxFileSize = DefaultUploadMaxFileSize
+	}
+	if cfg.Uploads.MaxRequestSize == 0 {
+		cfg.Uploads.MaxRequestSize = DefaultUploadMaxRequestSize
+	}
+	if cfg.Uploads.TTL == 0 {
+		cfg.Uploads.TTL = DefaultUploadTTL
+	}
+	if cfg.Uploads.CleanupInterval == 0 {
+		cfg.Uploads.CleanupInterval = DefaultUploadCleanupInterval
+	}
+	if cfg.Uploads.Storage == "" {
+		cfg.Uploads.Storage = DefaultUploadStorage
+	}
+	if cfg.Uploads.Dir == "" {
+		cfg.Uploads.Dir = filepath.Join(os.TempDir(), "sg-uploads")
+	}
+	if cfg.Uploads.Bucket == "" {
+		cfg.Uploads.Bucket = DefaultUploadBucket
+	}
+	if cfg.Webhooks.PollInterval == 0 {
+		cfg.Webhooks.PollInterval = DefaultWebhookPollInterval
+	}
+	if cfg.Webhooks.BatchSize == 0 {
+		cfg.Webhooks.BatchSize = DefaultWebhookBatchSize
+	}
+	if cfg.Webhooks.MaxAttempts == 0 {
+		cfg.Webhooks.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if cfg.Webhooks.BaseBackoff == 0 {
+		cfg.Webhooks.BaseBackoff = DefaultWebhookBaseBackoff
+	}
+	if cfg.Webhooks.MaxBackoff == 0 {
+		cfg.Webhooks.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if cfg.Webhooks.Lease == 0 {
+		cfg.Webhooks.Lease = DefaultWebhookLease
+	}
+	if cfg.Webhooks.Timeout == 0 {
+		cfg.Webhooks.Timeout = DefaultWebhookTimeout
+	}
+}
+
+// getEnvInt lee una variable de entorno numérica, devolviendo 0 si no está definida o es inválida
+func getEnvInt(key string) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// getEnvSeconds lee una variable de entorno expresada en segundos
+func getEnvSeconds(key string) time.Duration {
+	return time.Duration(getEnvInt(key)) * time.Second
 }
 
 // validate valida la configuración
@@ -160,6 +392,11 @@ func (c *Config) validate() error {
 		return fmt.Errorf("APP_ENV is required")
 	}
 
+	// Validación del almacenamiento de subidas
+	if c.Uploads.Storage != UploadStorageLocal && c.Uploads.Storage != UploadStorageS3 {
+		return fmt.Errorf("UPLOAD_STORAGE must be %s or %s", UploadStorageLocal, UploadStorageS3)
+	}
+
 	return nil
 }
 
@@ -185,6 +422,36 @@ func GetMiArgConfig() MiArgConfig {
 	return cfg.External.MiArg
 }
 
+// GetOutboxConfig retorna la configuración del worker de outbox
+func GetOutboxConfig() OutboxConfig {
+	return cfg.Outbox
+}
+
+// GetDraftConfig retorna la configuración de la limpieza de borradores
+func GetDraftConfig() DraftConfig {
+	return cfg.Drafts
+}
+
+// GetAssignmentConfig retorna la configuración de la asignación de solicitudes
+func GetAssignmentConfig() AssignmentConfig {
+	return cfg.Assignment
+}
+
+// GetIdempotencyConfig retorna la configuración de las claves de idempotencia
+func GetIdempotencyConfig() IdempotencyConfig {
+	return cfg.Idempotency
+}
+
+// GetUploadConfig retorna los límites de los archivos subidos
+func GetUploadConfig() UploadConfig {
+	return cfg.Uploads
+}
+
+// GetWebhookConfig retorna la configuración del worker de webhooks
+func GetWebhookConfig() WebhookConfig {
+	return cfg.Webhooks
+}
+
 // GetAppConfig retorna la configuración de la aplicación
 func GetAppConfig() AppConfig {
 	return cfg.App
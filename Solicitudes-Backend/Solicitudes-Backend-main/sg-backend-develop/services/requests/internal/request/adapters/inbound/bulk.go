@@ -0,0 +1,98 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	sdkmwr "github.com/teamcubation/sg-backend/pkg/rest/middlewares/gin"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/inbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// bulkItemStatus traduce el error de una solicitud de una acción masiva al código HTTP de la acción individual
+func bulkItemStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidStatusTransition), errors.Is(err, domain.ErrAssignmentConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrTrackNotUsed), errors.Is(err, domain.ErrMissingDocument):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrRequestAccessDenied):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// BulkVerifyRequests aplica la misma decisión de verificación a varias solicitudes y responde el
+// resultado de cada una. Responde 200 aunque fallen algunas solicitudes
+func (h *GinHandler) BulkVerifyRequests(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req transport.BulkVerificationJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	if req.VerificationType != "tasks" && req.VerificationType != "property" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid param"})
+		return
+	}
+
+	results, err := h.ucs.BulkVerifyRequests(c, transport.ToBulkVerificationDomain(&req, cuil))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidBulkAction) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToBulkResponse(results, bulkItemStatus))
+}
+
+// BulkValidateRequests valida o rechaza varias solicitudes y responde el resultado de cada una
+func (h *GinHandler) BulkValidateRequests(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req transport.BulkValidationJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	results, err := h.ucs.BulkValidateRequests(c, transport.ToBulkValidationDomain(&req, cuil))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidBulkAction) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToBulkResponse(results, bulkItemStatus))
+}
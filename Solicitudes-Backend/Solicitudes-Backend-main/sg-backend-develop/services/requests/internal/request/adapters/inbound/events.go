@@ -0,0 +1,112 @@
+package inbound
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	sdkmwr "github.com/teamcubation/sg-backend/pkg/rest/middlewares/gin"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/inbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// requestEventName es el nombre de los eventos del stream; el tipo de cambio va en los datos
+const requestEventName = "request"
+
+// GetRequestEvents envía por Server-Sent Events los cambios de estado de la solicitud del expediente
+func (h *GinHandler) GetRequestEvents(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	events, cancel, err := h.ucs.SubscribeRequestEvents(c, c.Param("id"), cuil)
+	if err != nil {
+		if errors.Is(err, domain.ErrRequestAccessDenied) {
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	defer cancel()
+
+	streamRequestEvents(c, events)
+}
+
+// GetUserEvents envía por Server-Sent Events los cambios de estado de las solicitudes del usuario. Con el
+// evento record_assigned se entera del número de expediente asignado a una solicitud recién creada
+func (h *GinHandler) GetUserEvents(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	events, cancel, err := h.ucs.SubscribeUserEvents(c, cuil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	defer cancel()
+
+	streamRequestEvents(c, events)
+}
+
+// GetQueueEvents envía por Server-Sent Events los cambios de estado de todas las solicitudes, para
+// actualizar las bandejas de verificación y validación
+func (h *GinHandler) GetQueueEvents(c *gin.Context) {
+	events, cancel, err := h.ucs.SubscribeQueueEvents(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	defer cancel()
+
+	streamRequestEvents(c, events)
+}
+
+// streamRequestEvents mantiene abierta la respuesta hasta que el cliente se desconecte. Los comentarios
+// periódicos evitan que los proxies cierren la conexión por inactividad
+func streamRequestEvents(c *gin.Context, events <-chan domain.RequestEvent) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(domain.RequestEventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event := <-events:
+			c.SSEvent(requestEventName, transport.ToRequestEventPresenter(event))
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
@@ -2,9 +2,12 @@ package inbound
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -14,6 +17,7 @@ import (
 
 	config "github.com/teamcubation/sg-backend/services/requests/internal/config"
 	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/inbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
 	ports "github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
 )
 
@@ -56,6 +60,9 @@ func (h *GinHandler) routes() {
 	router.GET(apiBase+"/ping", h.Ping)
 	router.GET(apiBase+"/address/autocomplete", h.GetSuggestions)
 	router.GET(apiBase+"/abl/ownership", h.CheckAblOwnership)
+	router.GET(apiBase+"/types", h.GetRequestTypes)
+	// Descubrimiento de las capacidades de las subidas reanudables (tus), sin autenticación
+	router.OPTIONS(protectedPrefix+"/uploads", h.GetUploadCapabilities)
 
 	// Rutas validadas (requieren validación de credenciales)
 	validated := router.Group(validatedPrefix)
@@ -68,19 +75,72 @@ func (h *GinHandler) routes() {
 	// Rutas protegidas (requieren JWT válido)
 	protected := router.Group(protectedPrefix)
 	{
-		// Aplicar middleware de validación JWT
+		// Aplicar middleware de validación JWT y resolver roles y permisos del usuario
 		protected.Use(sdkmwr.Validate(config.GetMiddlewareConfig().Auth))
+		protected.Use(sdkmwr.Authorize(sdkmwr.AuthorizeConfig{
+			Resolver:   sdkmwr.PermissionResolverFunc(h.resolvePrincipal),
+			ContextKey: config.GetMiddlewareConfig().Auth.ContextKey,
+		}))
+		// Reintentos seguros con el header Idempotency-Key. Las subidas reanudables se reintentan desde el
+		// Upload-Offset y no se leen completas en memoria
+		protected.Use(h.idempotent(protectedPrefix + "/uploads"))
+
+		verify := sdkmwr.RequirePermissions(domain.PermissionVerify)
+		validate := sdkmwr.RequirePermissions(domain.PermissionValidate)
+		assign := sdkmwr.RequirePermissions(domain.PermissionAssign)
+		readAll := sdkmwr.RequirePermissions(domain.PermissionReadAll)
+		webhooks := sdkmwr.RequirePermissions(domain.PermissionWebhooks)
+
 		protected.GET("/ping", h.ProtectedPing)
 		protected.POST("/create", h.CreateRequestByCuil)
+		protected.POST("/drafts", h.CreateDraft)
+		protected.GET("/drafts", h.GetDrafts)
+		protected.GET("/events", h.GetUserEvents)
+		protected.GET("/queue/events", readAll, h.GetQueueEvents)
+		protected.GET("/drafts/:id", h.GetDraft)
+		protected.PATCH("/drafts/:id", h.UpdateDraft)
+		protected.DELETE("/drafts/:id", h.DeleteDraft)
+		protected.PUT("/drafts/:id/files/:type", h.PutDraftFile)
+		protected.DELETE("/drafts/:id/files/:type", h.DeleteDraftFile)
+		protected.POST("/drafts/:id/submit", h.SubmitDraft)
+		protected.POST("/uploads", h.CreateUpload)
+		protected.HEAD("/uploads/:upload_id", h.HeadUpload)
+		protected.PATCH("/uploads/:upload_id", h.PatchUpload)
+		protected.DELETE("/uploads/:upload_id", h.DeleteUpload)
 		protected.PUT("/:id", h.UpdateRequestByCuil)
-		protected.PUT("/verification/:id", h.VerifyRequest)
-		protected.PUT("/validation/:id", h.ValidateRequest)
+		protected.GET("/:id/history", h.GetRequestHistory)
+		protected.GET("/:id/documents/rejected", h.GetRejectedDocuments)
+		protected.GET("/:id/documents/:type/versions", readAll, h.GetDocumentVersions)
+		protected.GET("/:id/documents/:type/versions/:version", readAll, h.GetDocumentVersion)
+		protected.GET("/:id/events", h.GetRequestEvents)
+		protected.GET("/:id/messages", h.GetMessageThread)
+		protected.POST("/:id/messages", h.PostMessage)
+		protected.PUT("/:id/messages/read", h.MarkMessagesRead)
+		protected.POST("/:id/withdraw", h.WithdrawRequest)
+		protected.PUT("/verification/:id", verify, h.VerifyRequest)
+		protected.POST("/verification/:id/claim", verify, h.ClaimRequest)
+		protected.DELETE("/verification/:id/claim", verify, h.ReleaseRequest)
+		protected.PUT("/verification/:id/assignment", assign, h.AssignRequest)
+		protected.PUT("/validation/:id", validate, h.ValidateRequest)
+		protected.POST("/verification/bulk", verify, h.BulkVerifyRequests)
+		protected.POST("/validation/bulk", validate, h.BulkValidateRequests)
 		protected.GET("/verification/owner", h.RequestsVerifications)
-		protected.GET("/verifications", h.GetAllVerifications)
-		protected.GET("/validations", h.GetAllValidations)
-		protected.GET("/documents", h.GetDocumentsByFileNumber)
-		protected.GET("/validations/documents", h.GetValidationDocumentsByFileNumber)
-		protected.GET("/documents/:id", h.GetDocumentByID)
+		protected.GET("/verifications", readAll, h.GetAllVerifications)
+		protected.GET("/validations", readAll, h.GetAllValidations)
+		protected.GET("/verifications/export", readAll, h.ExportVerifications)
+		protected.GET("/validations/export", readAll, h.ExportValidations)
+		protected.GET("/sla/breached", readAll, h.GetBreachedRequests)
+		protected.GET("/stats", readAll, h.GetStats)
+		protected.POST("/webhooks", webhooks, h.CreateWebhook)
+		protected.GET("/webhooks", webhooks, h.GetWebhooks)
+		protected.GET("/webhooks/:id", webhooks, h.GetWebhook)
+		protected.PUT("/webhooks/:id", webhooks, h.UpdateWebhook)
+		protected.DELETE("/webhooks/:id", webhooks, h.DeleteWebhook)
+		protected.GET("/webhooks/:id/deliveries", webhooks, h.GetWebhookDeliveries)
+		protected.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", webhooks, h.RedeliverWebhook)
+		protected.GET("/documents", readAll, h.GetDocumentsByFileNumber)
+		protected.GET("/validations/documents", readAll, h.GetValidationDocumentsByFileNumber)
+		protected.GET("/documents/:id", readAll, h.GetDocumentByID)
 		protected.GET("/get/all/cuil", h.GetAllRequestsByCuil)
 		protected.GET("get/all/id", h.GetAllRequestsByUserID)
 		protected.GET("get/id", h.GetRequestByID)
@@ -88,6 +148,89 @@ func (h *GinHandler) routes() {
 	}
 }
 
+// resolvePrincipal obtiene los roles y permisos del usuario identificado por el CUIL del token
+func (h *GinHandler) resolvePrincipal(ctx context.Context, cuil string) (*sdkmwr.Principal, error) {
+	access, err := h.ucs.GetUserAccess(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sdkmwr.Principal{
+		UserID:      access.UserID,
+		Roles:       access.Roles,
+		Permissions: access.Permissions,
+	}, nil
+}
+
+// canRead indica si el usuario autorizado puede leer las solicitudes de ownerID:
+// los ciudadanos solo pueden leer las propias
+func canRead(c *gin.Context, ownerID int64) bool {
+	principal, err := sdkmwr.GetPrincipal(c)
+	if err != nil {
+		return false
+	}
+
+	return principal.UserID == ownerID || principal.HasPermission(domain.PermissionReadAll)
+}
+
+// isRequestTypeError indica si la solicitud no cumple con la definición de su tipo de trámite
+func isRequestTypeError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidForm) ||
+		errors.Is(err, domain.ErrRequestTypeInactive) ||
+		errors.Is(err, domain.ErrRequestTypeNotFound)
+}
+
+// draftErrorStatus traduce los errores de los borradores a códigos HTTP
+func draftErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrDraftNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrDuplicateRequest):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrEmptyDraftDocument), errors.Is(err, domain.ErrInvalidUpload),
+		errors.Is(err, transport.ErrInvalidPayload), isRequestTypeError(err):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// uploadErrorResponse responde con el detalle de cada archivo rechazado cuando falló la validación de
+// los archivos subidos. list es el campo del payload con los archivos
+func uploadErrorResponse(c *gin.Context, err error, list string) bool {
+	var uploadErrs domain.UploadErrors
+	if !errors.As(err, &uploadErrs) {
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, transport.ToUploadErrorResponse(uploadErrs, list))
+	return true
+}
+
+// assignmentErrorStatus traduce los errores de la asignación de solicitudes a códigos HTTP
+func assignmentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrAssignmentConflict), errors.Is(err, domain.ErrTrackNotPending):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrTrackNotUsed), errors.Is(err, domain.ErrAssigneeNotVerifier):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// queueQuery arma la consulta de las bandejas. assigned=me filtra las solicitudes asignadas al usuario autorizado
+func queueQuery(c *gin.Context, q *transport.QueueQueryJson) (domain.QueueQuery, error) {
+	query := transport.ToQueueQueryDomain(q)
+	if q.Assigned == "me" {
+		principal, err := sdkmwr.GetPrincipal(c)
+		if err != nil {
+			return domain.QueueQuery{}, err
+		}
+		query.AssignedTo = principal.UserID
+	}
+
+	return query, nil
+}
+
 func (h *GinHandler) ProtectedPing(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Protected Pong!"})
 }
@@ -105,6 +248,13 @@ func (h *GinHandler) GetAllRequestsByUserID(c *gin.Context) {
 		return
 	}
 
+	if !canRead(c, userID) {
+		c.JSON(http.StatusForbidden, transport.ErrorResponse{
+			Error: domain.ErrRequestAccessDenied.Error(),
+		})
+		return
+	}
+
 	allRequests, err := h.ucs.GetAllRequestsByUserID(c, userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
@@ -180,7 +330,7 @@ func (h *GinHandler) CheckAblOwnership(c *gin.Context) {
 		return
 	}
 
-	ownership, err := h.ucs.CheckAblOwnership(c, cuil, ablNumb)
+	check, err := h.ucs.CheckAblOwnership(c, cuil, ablNumb)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
 			// Error: transport.ErrInternalServer.Error() + ":" + err.Error(),
@@ -190,12 +340,29 @@ func (h *GinHandler) CheckAblOwnership(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, transport.AblOwnershipResponse{
-		AblOwnership: ownership,
+		AblOwnership: check.Result == domain.OwnershipMatch,
+		Ownership:    transport.ToOwnershipPresenter(check),
 	})
 }
 
 func (h *GinHandler) GetAllVerifications(c *gin.Context) {
-	verifications, err := h.ucs.AllRequestsVerifications(c)
+	var query transport.QueueQueryJson
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidQueryParam.Error(),
+		})
+		return
+	}
+
+	queue, err := queueQuery(c, &query)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	verifications, err := h.ucs.AllRequestsVerifications(c, queue)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
 			Error: err.Error(),
@@ -203,11 +370,140 @@ func (h *GinHandler) GetAllVerifications(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, transport.ToVerificationListPresenter(verifications))
+	c.JSON(http.StatusOK, transport.ToVerificationPageResponse(verifications))
 }
 
 func (h *GinHandler) GetAllValidations(c *gin.Context) {
-	verifications, err := h.ucs.AllRequestsValidations(c)
+	var query transport.QueueQueryJson
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidQueryParam.Error(),
+		})
+		return
+	}
+
+	queue, err := queueQuery(c, &query)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	verifications, err := h.ucs.AllRequestsValidations(c, queue)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToVerificationPageResponse(verifications))
+}
+
+// ExportVerifications exporta la bandeja de verificación completa en CSV o XLSX
+func (h *GinHandler) ExportVerifications(c *gin.Context) {
+	h.exportQueue(c, "verificaciones", h.ucs.ExportVerifications)
+}
+
+// ExportValidations exporta la bandeja de validación completa en CSV o XLSX
+func (h *GinHandler) ExportValidations(c *gin.Context) {
+	h.exportQueue(c, "validaciones", h.ucs.ExportValidations)
+}
+
+// exportQueue envía la exportación a medida que se leen las solicitudes. La respuesta empieza con la primera
+// fila, por lo que un error posterior solo puede cortar el archivo
+func (h *GinHandler) exportQueue(c *gin.Context, name string, export func(context.Context, domain.QueueQuery, func(domain.Verification) error) error) {
+	var query transport.ExportQueryJson
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidQueryParam.Error(),
+		})
+		return
+	}
+
+	queue, err := queueQuery(c, &query.QueueQueryJson)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var writer transport.TableWriter
+	start := func() error {
+		contentType, extension := transport.ExportContentType(query.Format)
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().Format("20060102"), extension))
+		c.Status(http.StatusOK)
+
+		w, err := transport.NewTableWriter(c.Writer, query.Format, name)
+		if err != nil {
+			return err
+		}
+		writer = w
+		return writer.WriteRow(transport.ExportHeader)
+	}
+
+	err = export(c, queue, func(v domain.Verification) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.WriteRow(transport.ToExportRow(v))
+	})
+	if err == nil && writer == nil {
+		err = start()
+	}
+	if err != nil {
+		if writer == nil {
+			c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		log.Printf("export %s: %v", name, err)
+		return
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Printf("export %s: %v", name, err)
+	}
+}
+
+// GetBreachedRequests lista las solicitudes que superaron el plazo de la etapa en que se encuentran
+func (h *GinHandler) GetBreachedRequests(c *gin.Context) {
+	var query transport.BreachedQueryJson
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidQueryParam.Error(),
+		})
+		return
+	}
+
+	breached, err := h.ucs.BreachedRequests(c, domain.SLAStage(query.Stage))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToBreachedResponse(breached))
+}
+
+// GetStats devuelve los indicadores del backoffice, filtrables por rango de fechas de creación
+func (h *GinHandler) GetStats(c *gin.Context) {
+	var query transport.StatsQueryJson
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidQueryParam.Error(),
+		})
+		return
+	}
+
+	stats, err := h.ucs.GetStats(c, transport.ToStatsQueryDomain(&query))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
 			Error: err.Error(),
@@ -215,7 +511,7 @@ func (h *GinHandler) GetAllValidations(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, transport.ToVerificationListPresenter(verifications))
+	c.JSON(http.StatusOK, transport.ToStatsResponse(stats))
 }
 
 func (h *GinHandler) GetDocumentsByFileNumber(c *gin.Context) {
@@ -238,6 +534,8 @@ func (h *GinHandler) GetDocumentsByFileNumber(c *gin.Context) {
 
 	c.JSON(http.StatusOK, transport.DocumentsResponse{
 		Documents: transport.ToDocumentListPresenter(request, documents, code),
+		Ownership: transport.ToOwnershipPresenter(request.Ownership),
+		Related:   transport.ToRelatedRequestsPresenter(request.RelatedRequests),
 	})
 }
 
@@ -323,15 +621,31 @@ func (h *GinHandler) CreateRequestByCuil(c *gin.Context) {
 
 	req.Cuil = cuil
 	ctx := context.Background()
-	err = h.ucs.CreateRequestByCuil(ctx, transport.ToRequestDomain(&req))
+	request := transport.ToRequestDomain(&req)
+	err = h.ucs.CreateRequestByCuil(ctx, request)
 	if err != nil {
+		if uploadErrorResponse(c, err, "files") {
+			return
+		}
+		if isRequestTypeError(err) {
+			c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrDuplicateRequest) {
+			c.JSON(http.StatusConflict, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Request created successfully"})
+	c.JSON(http.StatusOK, transport.ToCreatedRequestResponse(request))
 }
 
 func (h *GinHandler) VerifyRequest(c *gin.Context) {
@@ -361,6 +675,24 @@ func (h *GinHandler) VerifyRequest(c *gin.Context) {
 	ctx := context.Background()
 	err = h.ucs.UpdateRequest(ctx, transport.ToVerifiedRequestDomain(&req))
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrTrackNotUsed) || errors.Is(err, domain.ErrInvalidDocumentDecision) {
+			c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrAssignmentConflict) {
+			c.JSON(http.StatusConflict, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
 			Error: err.Error(),
 		})
@@ -392,6 +724,12 @@ func (h *GinHandler) ValidateRequest(c *gin.Context) {
 	ctx := context.Background()
 	err = h.ucs.ValidateRequest(ctx, transport.ToValidateRequestDomain(&req))
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
 			Error: err.Error(),
 		})
@@ -441,6 +779,13 @@ func (h *GinHandler) GetRequestByID(c *gin.Context) {
 		return
 	}
 
+	if !canRead(c, request.UserID) {
+		c.JSON(http.StatusForbidden, transport.ErrorResponse{
+			Error: domain.ErrRequestAccessDenied.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"request": request})
 }
 
@@ -454,6 +799,13 @@ func (h *GinHandler) GetRequestExpCode(c *gin.Context) {
 		return
 	}
 
+	if !canRead(c, request.UserID) {
+		c.JSON(http.StatusForbidden, transport.ErrorResponse{
+			Error: domain.ErrRequestAccessDenied.Error(),
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"request": request})
 }
 
@@ -480,6 +832,27 @@ func (h *GinHandler) UpdateRequestByCuil(c *gin.Context) {
 	ctx := context.Background()
 
 	if err := h.ucs.UpdateRequestByFileNumber(ctx, request); err != nil {
+		if uploadErrorResponse(c, err, "files") {
+			return
+		}
+		if errors.Is(err, domain.ErrRequestAccessDenied) {
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrUnexpectedDocument) || errors.Is(err, domain.ErrMissingDocument) {
+			c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
 			Error: err.Error(),
 		})
@@ -488,3 +861,672 @@ func (h *GinHandler) UpdateRequestByCuil(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Request updated successfully"})
 }
+
+func (h *GinHandler) GetRequestHistory(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	history, err := h.ucs.GetRequestHistory(c, c.Param("id"), cuil)
+	if err != nil {
+		if errors.Is(err, domain.ErrRequestNotFound) {
+			c.JSON(http.StatusNotFound, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, domain.ErrRequestAccessDenied) {
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToHistoryResponse(history))
+}
+
+// GetDocumentVersions lista las versiones de un tipo de documento de la solicitud, de la más nueva a la más vieja
+func (h *GinHandler) GetDocumentVersions(c *gin.Context) {
+	docType, err := strconv.Atoi(c.Param("type"))
+	if err != nil || docType <= 0 {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidFileType.Error(),
+		})
+		return
+	}
+
+	versions, err := h.ucs.GetDocumentVersions(c, c.Param("id"), domain.DocumentTypeID(docType))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToDocumentVersionsResponse(versions))
+}
+
+// GetDocumentVersion devuelve una versión de un documento de la solicitud con su contenido
+func (h *GinHandler) GetDocumentVersion(c *gin.Context) {
+	docType, err := strconv.Atoi(c.Param("type"))
+	if err != nil || docType <= 0 {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidFileType.Error(),
+		})
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidVersion.Error(),
+		})
+		return
+	}
+
+	documentVersion, err := h.ucs.GetDocumentVersion(c, c.Param("id"), domain.DocumentTypeID(docType), version)
+	if err != nil {
+		if errors.Is(err, domain.ErrDocumentVersionNotFound) {
+			c.JSON(http.StatusNotFound, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToDocumentVersionPresenter(*documentVersion))
+}
+
+// GetRejectedDocuments devuelve los documentos rechazados por el verificador, que son los únicos que
+// se vuelven a subir al reenviar la solicitud
+func (h *GinHandler) GetRejectedDocuments(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	documents, err := h.ucs.GetRejectedDocuments(c, c.Param("id"), cuil)
+	if err != nil {
+		if errors.Is(err, domain.ErrRequestAccessDenied) {
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"documents": transport.ToRejectedDocumentList(documents)})
+}
+
+func (h *GinHandler) GetMessageThread(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	thread, err := h.ucs.GetMessageThread(c, c.Param("id"), cuil)
+	if err != nil {
+		if errors.Is(err, domain.ErrRequestAccessDenied) {
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToMessageThreadResponse(thread))
+}
+
+func (h *GinHandler) PostMessage(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req transport.MessageJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	err = h.ucs.PostMessage(c, c.Param("id"), cuil, transport.ToMessageDomain(&req))
+	if err != nil {
+		if uploadErrorResponse(c, err, "attachments") {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrEmptyMessage):
+			c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, domain.ErrRequestAccessDenied):
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, transport.MessageResponse{
+		Message: "Message sent successfully",
+	})
+}
+
+func (h *GinHandler) MarkMessagesRead(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.MarkMessagesRead(c, c.Param("id"), cuil); err != nil {
+		if errors.Is(err, domain.ErrRequestAccessDenied) {
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.MessageResponse{
+		Message: "Messages marked as read",
+	})
+}
+
+func (h *GinHandler) GetRequestTypes(c *gin.Context) {
+	requestTypes, err := h.ucs.GetRequestTypes(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToRequestTypesResponse(requestTypes))
+}
+
+func (h *GinHandler) CreateDraft(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req transport.DraftJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	draft, err := transport.ToDraftDomain(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.CreateDraft(c, cuil, draft); err != nil {
+		if uploadErrorResponse(c, err, "files") {
+			return
+		}
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, transport.ToDraftPresenter(draft))
+}
+
+func (h *GinHandler) GetDrafts(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	drafts, err := h.ucs.GetDrafts(c, cuil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToDraftsResponse(drafts))
+}
+
+func (h *GinHandler) GetDraft(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidDraftID.Error(),
+		})
+		return
+	}
+
+	draft, err := h.ucs.GetDraft(c, id, cuil)
+	if err != nil {
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToDraftPresenter(draft))
+}
+
+func (h *GinHandler) UpdateDraft(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidDraftID.Error(),
+		})
+		return
+	}
+
+	var req transport.DraftJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	draft, err := transport.ToDraftDomain(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	draft.ID = id
+
+	if err := h.ucs.UpdateDraft(c, cuil, draft); err != nil {
+		if uploadErrorResponse(c, err, "files") {
+			return
+		}
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	updated, err := h.ucs.GetDraft(c, id, cuil)
+	if err != nil {
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToDraftPresenter(updated))
+}
+
+func (h *GinHandler) DeleteDraft(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidDraftID.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.DeleteDraft(c, id, cuil); err != nil {
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.MessageResponse{
+		Message: "Draft deleted successfully",
+	})
+}
+
+func (h *GinHandler) PutDraftFile(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidDraftID.Error(),
+		})
+		return
+	}
+
+	docType, err := strconv.Atoi(c.Param("type"))
+	if err != nil || docType <= 0 {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidFileType.Error(),
+		})
+		return
+	}
+
+	var file transport.FileJson
+	if err := c.ShouldBindJSON(&file); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+	file.Type = transport.DocumentTypeIDJson(docType)
+
+	draft := &domain.Draft{
+		ID:        id,
+		Documents: []domain.DraftDocument{transport.ToDraftDocumentDomain(file)},
+	}
+
+	if err := h.ucs.UpdateDraft(c, cuil, draft); err != nil {
+		if uploadErrorResponse(c, err, "") {
+			return
+		}
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.MessageResponse{
+		Message: "Draft file saved successfully",
+	})
+}
+
+func (h *GinHandler) DeleteDraftFile(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidDraftID.Error(),
+		})
+		return
+	}
+
+	docType, err := strconv.Atoi(c.Param("type"))
+	if err != nil || docType <= 0 {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidFileType.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.DeleteDraftDocument(c, id, cuil, domain.DocumentTypeID(docType)); err != nil {
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.MessageResponse{
+		Message: "Draft file deleted successfully",
+	})
+}
+
+// SubmitDraft presenta el borrador. La solicitud se valida y se procesa igual que en CreateRequestByCuil
+func (h *GinHandler) SubmitDraft(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidDraftID.Error(),
+		})
+		return
+	}
+
+	draft, err := h.ucs.GetDraft(c, id, cuil)
+	if err != nil {
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	req, err := transport.ToDraftRequestDomain(draft, cuil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.SubmitDraft(c, req); err != nil {
+		if uploadErrorResponse(c, err, "files") {
+			return
+		}
+		c.JSON(draftErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToCreatedRequestResponse(req))
+}
+
+// WithdrawRequest da de baja la solicitud a pedido del ciudadano que la presentó
+func (h *GinHandler) WithdrawRequest(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req transport.WithdrawalJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	err = h.ucs.WithdrawRequest(c, c.Param("id"), cuil, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrWithdrawalReasonRequired), errors.Is(err, domain.ErrWithdrawalReasonTooLong):
+			c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, domain.ErrRequestAccessDenied):
+			c.JSON(http.StatusForbidden, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.MessageResponse{
+		Message: "Request withdrawn successfully",
+	})
+}
+
+// ClaimRequest asigna la solicitud, o uno de sus circuitos, al verificador autorizado
+func (h *GinHandler) ClaimRequest(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var query transport.ClaimQueryJson
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidQueryParam.Error(),
+		})
+		return
+	}
+
+	fileNumber := c.Param("id")
+	assignments, err := h.ucs.ClaimRequest(c, fileNumber, cuil, query.Track)
+	if err != nil {
+		c.JSON(assignmentErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToAssignmentsResponse(fileNumber, assignments))
+}
+
+// ReleaseRequest libera las asignaciones del verificador autorizado sobre la solicitud
+func (h *GinHandler) ReleaseRequest(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var query transport.ClaimQueryJson
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidQueryParam.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.ReleaseRequest(c, c.Param("id"), cuil, query.Track); err != nil {
+		c.JSON(assignmentErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.MessageResponse{
+		Message: "Request released successfully",
+	})
+}
+
+// AssignRequest reasigna la solicitud, o uno de sus circuitos, a otro verificador
+func (h *GinHandler) AssignRequest(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req transport.AssignmentJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	fileNumber := c.Param("id")
+	assignments, err := h.ucs.AssignRequest(c, fileNumber, cuil, req.Track, req.UserID)
+	if err != nil {
+		c.JSON(assignmentErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToAssignmentsResponse(fileNumber, assignments))
+}
@@ -0,0 +1,164 @@
+package inbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	sdkmwr "github.com/teamcubation/sg-backend/pkg/rest/middlewares/gin"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/inbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+const (
+	// idempotencyReplayedHeader indica que la respuesta es la guardada de una solicitud anterior con la misma clave
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+	// idempotentResponseKey guarda en el contexto la respuesta que reciben los reintentos, si difiere de la enviada
+	idempotentResponseKey = "idempotent_response"
+)
+
+// idempotent permite reintentar de forma segura las solicitudes que modifican datos. Si la solicitud trae el
+// header Idempotency-Key se guarda la respuesta y los reintentos con la misma clave, del mismo usuario y al
+// mismo endpoint, la reciben sin volver a procesarse. Las respuestas con error del servidor no se guardan,
+// para que el reintento se procese otra vez. Las rutas con alguno de los prefijos de skip no se procesan:
+// el cuerpo se lee completo para calcular la huella
+func (h *GinHandler) idempotent(skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(domain.IdempotencyHeader)
+		if key == "" || !isMutating(c.Request.Method) || hasAnyPrefix(c.FullPath(), skip) {
+			c.Next()
+			return
+		}
+
+		principal, err := sdkmwr.GetPrincipal(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, transport.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, transport.ErrorResponse{Error: transport.ErrInvalidPayload.Error()})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := sha256.Sum256(body)
+		record := &domain.IdempotencyRecord{
+			Scope:       fmt.Sprintf("%d %s %s", principal.UserID, c.Request.Method, c.Request.URL.Path),
+			Key:         key,
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+		}
+
+		stored, err := h.ucs.BeginIdempotentRequest(c, record)
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, domain.ErrInvalidIdempotencyKey):
+				status = http.StatusBadRequest
+			case errors.Is(err, domain.ErrIdempotencyKeyReused):
+				status = http.StatusUnprocessableEntity
+			case errors.Is(err, domain.ErrIdempotencyInProgress):
+				c.Header("Retry-After", strconv.Itoa(int(domain.IdempotencyLockTimeout.Seconds())))
+				status = http.StatusConflict
+			}
+			c.AbortWithStatusJSON(status, transport.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		if stored != nil {
+			c.Header(idempotencyReplayedHeader, "true")
+			c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+			c.Abort()
+			return
+		}
+
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// La solicitud ya terminó, se usa un contexto propio para no perder la respuesta si el cliente se desconectó
+		ctx := c.Request.Context()
+		if ctx.Err() != nil {
+			ctx = context.Background()
+		}
+
+		if writer.Status() >= http.StatusInternalServerError {
+			if err := h.ucs.ReleaseIdempotentRequest(ctx, record); err != nil {
+				log.Printf("idempotency: %v", err)
+			}
+			return
+		}
+
+		record.StatusCode = writer.Status()
+		record.ContentType = writer.Header().Get("Content-Type")
+		record.Body = writer.body.Bytes()
+		if response, ok := c.Get(idempotentResponseKey); ok {
+			body, err := json.Marshal(response)
+			if err != nil {
+				log.Printf("idempotency: error marshaling response: %v", err)
+				if err := h.ucs.ReleaseIdempotentRequest(ctx, record); err != nil {
+					log.Printf("idempotency: %v", err)
+				}
+				return
+			}
+			record.Body = body
+		}
+
+		if err := h.ucs.CompleteIdempotentRequest(ctx, record); err != nil {
+			log.Printf("idempotency: %v", err)
+		}
+	}
+}
+
+// setIdempotentResponse reemplaza la respuesta que se guarda para los reintentos con la misma Idempotency-Key.
+// Se usa cuando la respuesta lleva datos que no se deben guardar, como el secreto de un webhook
+func setIdempotentResponse(c *gin.Context, response any) {
+	c.Set(idempotentResponseKey, response)
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// capturingWriter copia el cuerpo de la respuesta mientras se envía al cliente
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
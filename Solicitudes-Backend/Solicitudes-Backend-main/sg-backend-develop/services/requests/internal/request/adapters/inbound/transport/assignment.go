@@ -0,0 +1,45 @@
+package transport
+
+import "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+
+// ClaimQueryJson query params de la toma y liberación de una solicitud. Sin circuito se toman todos
+type ClaimQueryJson struct {
+	Track string `form:"track" binding:"omitempty,oneof=tasks property"`
+}
+
+// AssignmentJson reasignación de una solicitud por parte de un supervisor
+type AssignmentJson struct {
+	Track  string `json:"track" binding:"omitempty,oneof=tasks property"`
+	UserID int64  `json:"user_id" binding:"required,min=1"`
+}
+
+type AssignmentsResponse struct {
+	FileNumber  string                `json:"file_number"`
+	Assignments []AssignmentPresenter `json:"assignments"`
+}
+
+type AssignmentPresenter struct {
+	Track      string     `json:"track"`
+	UserID     int64      `json:"user_id"`
+	AssignedBy int64      `json:"assigned_by"`
+	ClaimedAt  CustomTime `json:"claimed_at"`
+	ExpiresAt  CustomTime `json:"expires_at"`
+}
+
+func ToAssignmentsResponse(fileNumber string, assignments []domain.Assignment) AssignmentsResponse {
+	presenters := make([]AssignmentPresenter, len(assignments))
+	for i, a := range assignments {
+		presenters[i] = AssignmentPresenter{
+			Track:      string(a.Track),
+			UserID:     a.UserID,
+			AssignedBy: a.AssignedBy,
+			ClaimedAt:  CustomTime(a.ClaimedAt),
+			ExpiresAt:  CustomTime(a.ExpiresAt),
+		}
+	}
+
+	return AssignmentsResponse{
+		FileNumber:  fileNumber,
+		Assignments: presenters,
+	}
+}
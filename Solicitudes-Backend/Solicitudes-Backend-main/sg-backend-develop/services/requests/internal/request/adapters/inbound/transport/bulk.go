@@ -0,0 +1,85 @@
+package transport
+
+import (
+	"strings"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// BulkVerificationJson decisión sobre un circuito de verificación para varias solicitudes. documents tiene
+// el documento de verificación de cada expediente
+type BulkVerificationJson struct {
+	FileNumbers      []string          `json:"file_numbers" binding:"required"`
+	VerificationType string            `json:"verificationType" binding:"required"`
+	Observations     string            `json:"observations"`
+	Reference        string            `json:"reference"`
+	Documents        map[string]string `json:"documents"`
+}
+
+// BulkValidationJson validación o rechazo de varias solicitudes. documents tiene el documento de
+// autorización de cada expediente validado
+type BulkValidationJson struct {
+	FileNumbers  []string          `json:"file_numbers" binding:"required"`
+	IsValid      bool              `json:"is_valid"`
+	Observations string            `json:"observations"` // motivo del rechazo
+	Documents    map[string]string `json:"documents"`
+}
+
+type BulkResponse struct {
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []BulkResultPresenter `json:"results"`
+}
+
+type BulkResultPresenter struct {
+	FileNumber string `json:"file_number"`
+	Success    bool   `json:"success"`
+	Status     int    `json:"status"` // código HTTP que hubiera devuelto la acción individual
+	Error      string `json:"error,omitempty"`
+}
+
+func ToBulkVerificationDomain(req *BulkVerificationJson, cuil string) *domain.BulkVerification {
+	return &domain.BulkVerification{
+		Cuil:             cuil,
+		VerificationType: req.VerificationType,
+		Observations:     req.Observations,
+		Reference:        req.Reference,
+		FileNumbers:      req.FileNumbers,
+		Documents:        req.Documents,
+	}
+}
+
+func ToBulkValidationDomain(req *BulkValidationJson, cuil string) *domain.BulkValidation {
+	return &domain.BulkValidation{
+		Cuil:         cuil,
+		IsValid:      req.IsValid,
+		Observations: strings.TrimSpace(req.Observations),
+		FileNumbers:  req.FileNumbers,
+		Documents:    req.Documents,
+	}
+}
+
+// ToBulkResponse resume los resultados; status traduce el error de cada solicitud a un código HTTP
+func ToBulkResponse(results []domain.BulkResult, status func(error) int) BulkResponse {
+	response := BulkResponse{
+		Results: make([]BulkResultPresenter, len(results)),
+	}
+
+	for i, result := range results {
+		presenter := BulkResultPresenter{
+			FileNumber: result.FileNumber,
+			Success:    result.Err == nil,
+			Status:     200,
+		}
+		if result.Err != nil {
+			presenter.Status = status(result.Err)
+			presenter.Error = result.Err.Error()
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+		response.Results[i] = presenter
+	}
+
+	return response
+}
@@ -0,0 +1,47 @@
+package transport
+
+import domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+
+type DocumentVersionPresenter struct {
+	DocumentID int64      `json:"documentId,string"`
+	Type       int        `json:"type"`
+	Title      string     `json:"title"`
+	Version    int        `json:"version"`
+	GedoCode   string     `json:"gedoCode"`
+	Filename   string     `json:"filename,omitempty"`
+	UploadedBy string     `json:"uploadedBy,omitempty"`
+	Superseded bool       `json:"superseded"`
+	Decision   string     `json:"decision"`
+	Reason     string     `json:"reason,omitempty"`
+	CreatedAt  CustomTime `json:"createdAt"`
+	Content    string     `json:"content,omitempty"`
+}
+
+type DocumentVersionsResponse struct {
+	Versions []DocumentVersionPresenter `json:"versions"`
+}
+
+func ToDocumentVersionPresenter(v domain.DocumentVersion) DocumentVersionPresenter {
+	return DocumentVersionPresenter{
+		DocumentID: v.ID,
+		Type:       v.Type,
+		Title:      v.Title,
+		Version:    v.Version,
+		GedoCode:   v.GedoCode,
+		Filename:   v.Filename,
+		UploadedBy: v.UploadedBy,
+		Superseded: v.Superseded,
+		Decision:   string(v.Decision()),
+		Reason:     v.Observations,
+		CreatedAt:  CustomTime(v.CreatedAt),
+		Content:    v.Content,
+	}
+}
+
+func ToDocumentVersionsResponse(list []domain.DocumentVersion) DocumentVersionsResponse {
+	versions := make([]DocumentVersionPresenter, len(list))
+	for i, v := range list {
+		versions[i] = ToDocumentVersionPresenter(v)
+	}
+	return DocumentVersionsResponse{Versions: versions}
+}
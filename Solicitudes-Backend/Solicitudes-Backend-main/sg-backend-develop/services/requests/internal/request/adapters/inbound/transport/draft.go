@@ -0,0 +1,151 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// draftFields son los campos del payload de alta (RequestJson) que se pueden guardar en un borrador
+var draftFields = map[string]bool{
+	"request_type_id":    true,
+	"form_data":          true,
+	"property_id":        true,
+	"address_street":     true,
+	"address_number":     true,
+	"address_abl_number": true,
+	"ablDebt":            true,
+	"commonZone":         true,
+	"userType":           true,
+	"selectedActivity":   true,
+	"projectDescription": true,
+	"estimatedTime":      true,
+	"insurance":          true,
+}
+
+// DraftJson es un payload de alta parcial: cualquier subconjunto de los campos de RequestJson, incluidos los archivos
+type DraftJson map[string]json.RawMessage
+
+type DraftsResponse struct {
+	Drafts []DraftPresenter `json:"drafts"`
+}
+
+type DraftPresenter struct {
+	ID        int64                    `json:"id"`
+	Fields    map[string]any           `json:"fields"`
+	Files     []DraftDocumentPresenter `json:"files"`
+	CreatedAt CustomTime               `json:"created_at"`
+	UpdatedAt CustomTime               `json:"updated_at"`
+}
+
+type DraftDocumentPresenter struct {
+	Type      int        `json:"type"`
+	Name      string     `json:"name"`
+	UpdatedAt CustomTime `json:"updated_at"`
+}
+
+// ToDraftDomain separa los campos y los archivos del payload, rechazando los campos desconocidos
+// y los valores que no se pueden decodificar como RequestJson
+func ToDraftDomain(req DraftJson) (*domain.Draft, error) {
+	draft := &domain.Draft{
+		Fields:    map[string]any{},
+		Documents: []domain.DraftDocument{},
+	}
+
+	for name, raw := range req {
+		if name == "files" {
+			var files []FileJson
+			if err := json.Unmarshal(raw, &files); err != nil {
+				return nil, fmt.Errorf("%w: files", ErrInvalidPayload)
+			}
+			for _, file := range files {
+				draft.Documents = append(draft.Documents, ToDraftDocumentDomain(file))
+			}
+			continue
+		}
+
+		if !draftFields[name] {
+			return nil, fmt.Errorf("%w: unknown field %s", ErrInvalidPayload, name)
+		}
+
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPayload, name)
+		}
+		draft.Fields[name] = value
+	}
+
+	if _, err := toRequestJson(draft.Fields); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	return draft, nil
+}
+
+func ToDraftDocumentDomain(file FileJson) domain.DraftDocument {
+	return domain.DraftDocument{
+		Type:    domain.DocumentTypeID(file.Type),
+		Name:    file.Name,
+		Content: file.Content,
+	}
+}
+
+// ToDraftRequestDomain arma la solicitud a presentar a partir de los campos guardados en el borrador.
+// Los archivos los agrega el caso de uso
+func ToDraftRequestDomain(draft *domain.Draft, cuil string) (*domain.Request, error) {
+	reqJson, err := toRequestJson(draft.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	reqJson.Cuil = cuil
+	req := ToRequestDomain(reqJson)
+	req.ID = draft.ID
+
+	return req, nil
+}
+
+func ToDraftPresenter(draft *domain.Draft) DraftPresenter {
+	files := make([]DraftDocumentPresenter, len(draft.Documents))
+	for i, doc := range draft.Documents {
+		files[i] = DraftDocumentPresenter{
+			Type:      int(doc.Type),
+			Name:      doc.Name,
+			UpdatedAt: CustomTime(doc.UpdatedAt),
+		}
+	}
+
+	return DraftPresenter{
+		ID:        draft.ID,
+		Fields:    draft.Fields,
+		Files:     files,
+		CreatedAt: CustomTime(draft.CreatedAt),
+		UpdatedAt: CustomTime(draft.UpdatedAt),
+	}
+}
+
+func ToDraftsResponse(drafts []domain.Draft) DraftsResponse {
+	presenters := make([]DraftPresenter, len(drafts))
+	for i := range drafts {
+		presenters[i] = ToDraftPresenter(&drafts[i])
+	}
+
+	return DraftsResponse{
+		Drafts: presenters,
+	}
+}
+
+func toRequestJson(fields map[string]any) (*RequestJson, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqJson RequestJson
+	if err := json.Unmarshal(data, &reqJson); err != nil {
+		return nil, err
+	}
+
+	return &reqJson, nil
+}
@@ -6,7 +6,12 @@ import "errors"
 var (
 	ErrInvalidPayload     = errors.New("invalid request payload")
 	ErrMissingQueryParam  = errors.New("missing required query parameter")
+	ErrInvalidQueryParam  = errors.New("invalid query parameter")
 	ErrInvalidUserID      = errors.New("invalid user ID")
+	ErrInvalidDraftID     = errors.New("invalid draft ID")
+	ErrInvalidWebhookID   = errors.New("invalid webhook ID")
+	ErrInvalidFileType    = errors.New("invalid file type")
+	ErrInvalidVersion     = errors.New("invalid document version")
 	ErrInvalidABLNumber   = errors.New("invalid ABL number")
 	ErrInternalServer     = errors.New("internal server error")
 	ErrNoSuggestionsFound = errors.New("no suggestions found")
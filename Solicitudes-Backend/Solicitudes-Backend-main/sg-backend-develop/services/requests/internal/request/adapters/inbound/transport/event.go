@@ -0,0 +1,27 @@
+package transport
+
+import "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+
+type RequestEventPresenter struct {
+	RequestID        int64      `json:"request_id"`
+	FileNumber       string     `json:"file_number,omitempty"`
+	Type             string     `json:"type"`
+	Track            string     `json:"track"`
+	PreviousStatusID int        `json:"previous_status_id,omitempty"`
+	StatusID         int        `json:"status_id"`
+	HasObservations  bool       `json:"has_observations"`
+	Date             CustomTime `json:"date"`
+}
+
+func ToRequestEventPresenter(event domain.RequestEvent) RequestEventPresenter {
+	return RequestEventPresenter{
+		RequestID:        event.RequestID,
+		FileNumber:       event.FileNumber,
+		Type:             string(event.Type),
+		Track:            string(event.Track),
+		PreviousStatusID: int(event.PreviousStatus),
+		StatusID:         int(event.Status),
+		HasObservations:  event.HasObservations,
+		Date:             CustomTime(event.Date),
+	}
+}
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// Formatos de exportación de las bandejas
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ExportQueryJson query params de la exportación: los mismos filtros y orden de la bandeja, sin paginar
+type ExportQueryJson struct {
+	QueueQueryJson
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+}
+
+// ExportHeader encabezado de las columnas exportadas
+var ExportHeader = []string{
+	"Expediente",
+	"Tipo de trámite",
+	"Fecha de presentación",
+	"Estado",
+	"Estado tareas",
+	"Estado potestad",
+	"Solicitante",
+	"CUIL",
+	"Domicilio",
+	"Actividades declaradas",
+}
+
+// TableWriter escribe una exportación fila por fila, sin retener las filas en memoria
+type TableWriter interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+// NewTableWriter crea el writer del formato pedido. Sin formato se exporta en CSV
+func NewTableWriter(w io.Writer, format, sheetName string) (TableWriter, error) {
+	switch format {
+	case ExportFormatXLSX:
+		return newXLSXWriter(w, sheetName)
+	case ExportFormatCSV, "":
+		return newCSVWriter(w)
+	}
+	return nil, fmt.Errorf("%w: format %s", ErrInvalidQueryParam, format)
+}
+
+// ExportContentType devuelve el Content-Type y la extensión del archivo del formato
+func ExportContentType(format string) (string, string) {
+	if format == ExportFormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", ExportFormatXLSX
+	}
+	return "text/csv; charset=utf-8", ExportFormatCSV
+}
+
+func ToExportRow(v domain.Verification) []string {
+	return []string{
+		v.RecordNumber,
+		v.RequestType,
+		v.DeliveryDate,
+		strings.ToLower(v.Status),
+		strings.ToLower(v.StatusTask),
+		strings.ToLower(v.StatusProperty),
+		v.RequesterFullName,
+		v.RequesterCuil,
+		v.RequesterAddress,
+		strings.Join(v.Activities, "; "),
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+// newCSVWriter escribe el BOM de UTF-8 para que las planillas de cálculo reconozcan los acentos
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) WriteRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = escapeFormula(cell)
+	}
+	return c.writer.Write(escaped)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// escapeFormula evita que las planillas de cálculo interpreten como fórmula los valores cargados por los ciudadanos
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
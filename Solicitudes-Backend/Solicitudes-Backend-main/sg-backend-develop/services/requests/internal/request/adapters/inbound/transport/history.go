@@ -0,0 +1,42 @@
+package transport
+
+import "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+
+type HistoryResponse struct {
+	FileNumber string                   `json:"file_number"`
+	Events     []TimelineEventPresenter `json:"events"`
+}
+
+type TimelineEventPresenter struct {
+	Date           CustomTime `json:"date"`
+	Type           string     `json:"type"`
+	Track          string     `json:"track,omitempty"`
+	PreviousStatus string     `json:"previous_status,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	Actor          string     `json:"actor,omitempty"`
+	Observations   string     `json:"observations,omitempty"`
+	DocumentType   int        `json:"document_type,omitempty"`
+	DocumentName   string     `json:"document_name,omitempty"`
+}
+
+func ToHistoryResponse(history *domain.RequestHistory) HistoryResponse {
+	events := make([]TimelineEventPresenter, len(history.Events))
+	for i, event := range history.Events {
+		events[i] = TimelineEventPresenter{
+			Date:           CustomTime(event.Date),
+			Type:           string(event.Type),
+			Track:          string(event.Track),
+			PreviousStatus: event.PreviousStatusLabel,
+			Status:         event.StatusLabel,
+			Actor:          event.ActorName,
+			Observations:   event.Observations,
+			DocumentType:   event.DocumentType,
+			DocumentName:   event.DocumentName,
+		}
+	}
+
+	return HistoryResponse{
+		FileNumber: history.FileNumber,
+		Events:     events,
+	}
+}
@@ -0,0 +1,75 @@
+package transport
+
+import "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+
+type MessageJson struct {
+	Content     string     `json:"content"`
+	Attachments []FileJson `json:"attachments"`
+}
+
+type MessageThreadResponse struct {
+	FileNumber string             `json:"file_number"`
+	Unread     int                `json:"unread"`
+	Messages   []MessagePresenter `json:"messages"`
+}
+
+type MessagePresenter struct {
+	ID          int64                        `json:"id"`
+	Author      string                       `json:"author,omitempty"`
+	FromStaff   bool                         `json:"from_staff"`
+	Content     string                       `json:"content"`
+	Attachments []MessageAttachmentPresenter `json:"attachments"`
+	Read        bool                         `json:"read"`
+	CreatedAt   CustomTime                   `json:"created_at"`
+}
+
+type MessageAttachmentPresenter struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+func ToMessageDomain(req *MessageJson) *domain.Message {
+	attachments := make([]domain.MessageAttachment, 0, len(req.Attachments))
+	for _, file := range req.Attachments {
+		attachments = append(attachments, domain.MessageAttachment{
+			Name:    file.Name,
+			Content: file.Content,
+		})
+	}
+
+	return &domain.Message{
+		Content:     req.Content,
+		Attachments: attachments,
+	}
+}
+
+func ToMessageThreadResponse(thread *domain.MessageThread) MessageThreadResponse {
+	messages := make([]MessagePresenter, len(thread.Messages))
+	for i, msg := range thread.Messages {
+		attachments := make([]MessageAttachmentPresenter, len(msg.Attachments))
+		for j, attachment := range msg.Attachments {
+			attachments[j] = MessageAttachmentPresenter{
+				ID:      attachment.DocumentID,
+				Name:    attachment.Name,
+				Content: attachment.Content,
+			}
+		}
+
+		messages[i] = MessagePresenter{
+			ID:          msg.ID,
+			Author:      msg.AuthorName,
+			FromStaff:   msg.UserID != thread.UserID,
+			Content:     msg.Content,
+			Attachments: attachments,
+			Read:        msg.Read,
+			CreatedAt:   CustomTime(msg.CreatedAt),
+		}
+	}
+
+	return MessageThreadResponse{
+		FileNumber: thread.FileNumber,
+		Unread:     thread.Unread(),
+		Messages:   messages,
+	}
+}
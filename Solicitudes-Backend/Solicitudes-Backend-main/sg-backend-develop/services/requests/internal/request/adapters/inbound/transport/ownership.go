@@ -0,0 +1,31 @@
+package transport
+
+import (
+	"math"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// OwnershipPresenter resultado de la verificación de titularidad: match, possible_match (requiere
+// revisión manual) o no_match
+type OwnershipPresenter struct {
+	Result       string  `json:"result"`
+	Method       string  `json:"method"`
+	Score        float64 `json:"score"`
+	MatchedOwner string  `json:"matched_owner,omitempty"`
+	Explanation  string  `json:"explanation"`
+}
+
+func ToOwnershipPresenter(check *domain.OwnershipCheck) *OwnershipPresenter {
+	if check == nil {
+		return nil
+	}
+
+	return &OwnershipPresenter{
+		Result:       string(check.Result),
+		Method:       string(check.Method),
+		Score:        math.Round(check.Score*100) / 100,
+		MatchedOwner: check.MatchedOwner,
+		Explanation:  check.Explanation,
+	}
+}
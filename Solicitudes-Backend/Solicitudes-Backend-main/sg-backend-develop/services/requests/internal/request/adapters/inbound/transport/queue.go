@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// QueueQueryJson query params de las bandejas de verificación y validación
+type QueueQueryJson struct {
+	Page           int       `form:"page" binding:"omitempty,min=1"`
+	PageSize       int       `form:"page_size" binding:"omitempty,min=1"`
+	Status         int       `form:"status" binding:"omitempty,min=1"`
+	StatusTasks    int       `form:"status_tasks" binding:"omitempty,min=1"`
+	StatusProperty int       `form:"status_property" binding:"omitempty,min=1"`
+	DateFrom       time.Time `form:"date_from" time_format:"2006-01-02"`
+	DateTo         time.Time `form:"date_to" time_format:"2006-01-02"`
+	Street         string    `form:"street"`
+	Cuil           string    `form:"cuil"`
+	FileNumber     string    `form:"file_number"`
+	SortBy         string    `form:"sort_by" binding:"omitempty,oneof=created_at file_number status street requester"`
+	Order          string    `form:"order" binding:"omitempty,oneof=asc desc"`
+	Assigned       string    `form:"assigned" binding:"omitempty,oneof=me"`
+}
+
+type VerificationPageResponse struct {
+	Items    []VerificationRequestPresenter `json:"items"`
+	Total    int64                          `json:"total"`
+	Page     int                            `json:"page"`
+	PageSize int                            `json:"page_size"`
+}
+
+func ToQueueQueryDomain(q *QueueQueryJson) domain.QueueQuery {
+	query := domain.QueueQuery{
+		Page:           q.Page,
+		PageSize:       q.PageSize,
+		Status:         domain.RequestStatus(q.Status),
+		StatusTasks:    domain.RequestStatus(q.StatusTasks),
+		StatusProperty: domain.RequestStatus(q.StatusProperty),
+		CreatedFrom:    q.DateFrom,
+		Street:         q.Street,
+		Cuil:           q.Cuil,
+		FileNumber:     q.FileNumber,
+		SortBy:         domain.QueueSortField(q.SortBy),
+		SortDesc:       q.Order == "desc",
+	}
+
+	// date_to incluye el día completo
+	if !q.DateTo.IsZero() {
+		query.CreatedTo = q.DateTo.AddDate(0, 0, 1)
+	}
+
+	return query
+}
+
+func ToVerificationPageResponse(page *domain.VerificationPage) VerificationPageResponse {
+	return VerificationPageResponse{
+		Items:    ToVerificationListPresenter(page.Items),
+		Total:    page.Total,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}
+}
+
+// BreachedQueryJson query params del listado de solicitudes vencidas
+type BreachedQueryJson struct {
+	Stage string `form:"stage" binding:"omitempty,oneof=verification validation citizen_response"`
+}
+
+type BreachedResponse struct {
+	Items []VerificationRequestPresenter `json:"items"`
+	Total int                            `json:"total"`
+}
+
+func ToBreachedResponse(list []domain.Verification) BreachedResponse {
+	return BreachedResponse{
+		Items: ToVerificationListPresenter(list),
+		Total: len(list),
+	}
+}
@@ -0,0 +1,48 @@
+package transport
+
+import "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+
+// CreatedRequestResponse respuesta del alta de una solicitud. Si la propiedad tiene otras solicitudes
+// activas que se superponen se informan como advertencia
+type CreatedRequestResponse struct {
+	Message         string                    `json:"message"`
+	Warning         string                    `json:"warning,omitempty"`
+	RelatedRequests []RelatedRequestPresenter `json:"related_requests,omitempty"`
+}
+
+type RelatedRequestPresenter struct {
+	FileNumber    string     `json:"file_number"`
+	Status        string     `json:"status"`
+	CreatedAt     CustomTime `json:"created_at"`
+	EstimatedTime int64      `json:"estimated_time"`
+	EndDate       CustomTime `json:"end_date"`
+	Activities    []string   `json:"overlapping_activities"`
+	SameApplicant bool       `json:"same_applicant"`
+}
+
+func ToCreatedRequestResponse(req *domain.Request) CreatedRequestResponse {
+	response := CreatedRequestResponse{
+		Message:         "Request created successfully",
+		RelatedRequests: ToRelatedRequestsPresenter(req.RelatedRequests),
+	}
+	if len(req.RelatedRequests) > 0 {
+		response.Warning = "La propiedad tiene otras solicitudes activas con actividades y plazos superpuestos"
+	}
+	return response
+}
+
+func ToRelatedRequestsPresenter(related []domain.RelatedRequest) []RelatedRequestPresenter {
+	presenters := make([]RelatedRequestPresenter, len(related))
+	for i, r := range related {
+		presenters[i] = RelatedRequestPresenter{
+			FileNumber:    r.FileNumber,
+			Status:        r.Status,
+			CreatedAt:     CustomTime(r.CreatedAt),
+			EstimatedTime: r.EstimatedTime,
+			EndDate:       CustomTime(r.EndDate),
+			Activities:    r.Activities,
+			SameApplicant: r.SameApplicant,
+		}
+	}
+	return presenters
+}
@@ -0,0 +1,92 @@
+package transport
+
+import "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+
+type RequestTypesResponse struct {
+	RequestTypes []RequestTypePresenter `json:"request_types"`
+}
+
+type RequestTypePresenter struct {
+	ID          int                         `json:"id"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description,omitempty"`
+	Fields      []FormFieldPresenter        `json:"fields"`
+	Documents   []RequiredDocumentPresenter `json:"documents"`
+	Tracks      []string                    `json:"tracks"`
+}
+
+type FormFieldPresenter struct {
+	Name      string   `json:"name"`
+	Label     string   `json:"label"`
+	Kind      string   `json:"kind"`
+	Required  bool     `json:"required"`
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	MaxLength int      `json:"max_length,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+	Options   []string `json:"options,omitempty"`
+}
+
+type RequiredDocumentPresenter struct {
+	Type         int      `json:"type"`
+	Label        string   `json:"label"`
+	UserTypes    []string `json:"user_types,omitempty"`
+	RequiredWhen string   `json:"required_when,omitempty"`
+	Optional     bool     `json:"optional"`
+	Track        string   `json:"track,omitempty"`
+	MimeTypes    []string `json:"mime_types"`
+}
+
+func ToRequestTypesResponse(requestTypes []domain.RequestType) RequestTypesResponse {
+	presenters := make([]RequestTypePresenter, len(requestTypes))
+	for i, rt := range requestTypes {
+		fields := make([]FormFieldPresenter, len(rt.Fields))
+		for j, f := range rt.Fields {
+			fields[j] = FormFieldPresenter{
+				Name:      f.Name,
+				Label:     f.Label,
+				Kind:      string(f.Kind),
+				Required:  f.Required,
+				Min:       f.Min,
+				Max:       f.Max,
+				MaxLength: f.MaxLength,
+				Pattern:   f.Pattern,
+				Options:   f.Options,
+			}
+		}
+
+		uploadTypes := rt.UploadTypes()
+		documents := make([]RequiredDocumentPresenter, len(rt.Documents))
+		for j, d := range rt.Documents {
+			userTypes := make([]string, len(d.UserTypes))
+			for k, ut := range d.UserTypes {
+				userTypes[k] = string(ut)
+			}
+			documents[j] = RequiredDocumentPresenter{
+				Type:         int(d.Type),
+				Label:        d.Label,
+				UserTypes:    userTypes,
+				RequiredWhen: d.RequiredWhen,
+				Optional:     d.Optional,
+				Track:        string(d.Track),
+				MimeTypes:    uploadTypes[d.Type],
+			}
+		}
+
+		tracks := make([]string, len(rt.Tracks))
+		for j, track := range rt.Tracks {
+			tracks[j] = string(track)
+		}
+
+		presenters[i] = RequestTypePresenter{
+			ID:          rt.ID,
+			Name:        rt.Name,
+			Description: rt.Description,
+			Fields:      fields,
+			Documents:   documents,
+			Tracks:      tracks,
+		}
+	}
+
+	return RequestTypesResponse{RequestTypes: presenters}
+}
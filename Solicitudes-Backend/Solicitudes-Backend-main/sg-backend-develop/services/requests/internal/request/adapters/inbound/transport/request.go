@@ -19,23 +19,27 @@ type FileJson struct {
 	Type    DocumentTypeIDJson `json:"type"`
 	Name    string             `json:"name"`
 	Content string             `json:"content"`
+	// Subida reanudable con el contenido, en lugar de enviarlo en base64. Solo en el alta y el reenvío
+	UploadID string `json:"upload_id,omitempty"`
 }
 
 // RequestJson represents the JSON structure for requests
 type RequestJson struct {
 	Cuil               string
-	PropertyID         string     `json:"property_id"`
-	AddressStreet      string     `json:"address_street"`
-	AddressNumber      string     `json:"address_number"`
-	AddressABLNumber   string     `json:"address_abl_number"`
-	ABLDebt            string     `json:"ablDebt"`
-	CommonZone         string     `json:"commonZone"`
-	UserType           string     `json:"userType"`
-	SelectedActivities string     `json:"selectedActivity"`
-	ProjectDesc        string     `json:"projectDescription"`
-	EstimatedTime      string     `json:"estimatedTime"`
-	Insurance          string     `json:"insurance"`
-	Files              []FileJson `json:"files"`
+	RequestTypeID      string         `json:"request_type_id"`
+	FormData           map[string]any `json:"form_data"`
+	PropertyID         string         `json:"property_id"`
+	AddressStreet      string         `json:"address_street"`
+	AddressNumber      string         `json:"address_number"`
+	AddressABLNumber   string         `json:"address_abl_number"`
+	ABLDebt            string         `json:"ablDebt"`
+	CommonZone         string         `json:"commonZone"`
+	UserType           string         `json:"userType"`
+	SelectedActivities string         `json:"selectedActivity"`
+	ProjectDesc        string         `json:"projectDescription"`
+	EstimatedTime      string         `json:"estimatedTime"`
+	Insurance          string         `json:"insurance"`
+	Files              []FileJson     `json:"files"`
 }
 
 type VerifiedRequestJson struct {
@@ -46,13 +50,22 @@ type VerifiedRequestJson struct {
 	FinalVerificationDocument string   `json:"finalVerificationDocument"`
 	Reference                 string   `json:"reference"`
 	AssignedTask              []string `json:"assignedTask"`
+	// Decisión sobre cada documento del circuito. Sin decisiones se observa el circuito completo
+	Documents []DocumentDecisionJson `json:"documents"`
+}
+
+type DocumentDecisionJson struct {
+	DocumentID int64  `json:"documentId,string"`
+	Decision   string `json:"decision"` // accepted o rejected
+	Reason     string `json:"reason"`
 }
 
 type ValidateRequestJson struct {
-	FileNumber  string
-	Cuil        string
-	IsValid     bool   `json:"is_valid"`
-	FileContent string `json:"authorizationDocument"`
+	FileNumber   string
+	Cuil         string
+	IsValid      bool   `json:"is_valid"`
+	FileContent  string `json:"authorizationDocument"`
+	Observations string `json:"observations"` // motivo del rechazo
 }
 
 // ToRequestDomain converts a RequestJson to a domain Request
@@ -75,6 +88,8 @@ func ToRequestDomain(reqJson *RequestJson) *domain.Request {
 
 	propertyID, _ := strconv.ParseInt(reqJson.PropertyID, 10, 64)
 
+	requestTypeID, _ := strconv.Atoi(reqJson.RequestTypeID)
+
 	var activities []int
 	if err := json.Unmarshal([]byte(reqJson.SelectedActivities), &activities); err != nil {
 		fmt.Println(err.Error())
@@ -87,6 +102,8 @@ func ToRequestDomain(reqJson *RequestJson) *domain.Request {
 			ABLNumber: ablNumber,
 		},
 		Cuil:               reqJson.Cuil,
+		RequestTypeID:      requestTypeID,
+		FormData:           reqJson.FormData,
 		PropertyID:         propertyID,
 		ABLDebt:            reqJson.ABLDebt,
 		CommonZone:         commonZone,
@@ -118,15 +135,29 @@ func ToVerifiedRequestDomain(req *VerifiedRequestJson) *domain.VerifiedRequest {
 		FinalVerificationDocument: req.FinalVerificationDocument,
 		Reference:                 req.Reference,
 		SelectedActivities:        intArr,
+		Documents:                 toDocumentDecisionList(req.Documents),
+	}
+}
+
+func toDocumentDecisionList(list []DocumentDecisionJson) []domain.DocumentDecision {
+	decisions := make([]domain.DocumentDecision, len(list))
+	for i, d := range list {
+		decisions[i] = domain.DocumentDecision{
+			DocumentID: d.DocumentID,
+			Status:     domain.DocumentDecisionStatus(d.Decision),
+			Reason:     d.Reason,
+		}
 	}
+	return decisions
 }
 
 func ToValidateRequestDomain(req *ValidateRequestJson) *domain.ValidateRequest {
 	return &domain.ValidateRequest{
-		FileNumber:  req.FileNumber,
-		Cuil:        req.Cuil,
-		IsValid:     req.IsValid,
-		FileContent: req.FileContent,
+		FileNumber:   req.FileNumber,
+		Cuil:         req.Cuil,
+		IsValid:      req.IsValid,
+		FileContent:  req.FileContent,
+		Observations: strings.TrimSpace(req.Observations),
 	}
 }
 
@@ -139,9 +170,10 @@ func toDocumentRequestList(files []FileJson) []domain.DocumentRequest {
 	result := make([]domain.DocumentRequest, 0, len(files))
 	for _, file := range files {
 		result = append(result, domain.DocumentRequest{
-			Name:    file.Name,
-			Type:    domain.DocumentTypeID(file.Type),
-			Content: file.Content,
+			Name:     file.Name,
+			Type:     domain.DocumentTypeID(file.Type),
+			Content:  file.Content,
+			UploadID: file.UploadID,
 		})
 	}
 	return result
@@ -228,6 +260,10 @@ func ToRequestListPresenter(requests []domain.Request) []GetAllReqPresenterReque
 		if fileNumber == "" {
 			fileNumber = "En Proceso"
 		}
+		requestType := req.RequestTypeName
+		if requestType == "" {
+			requestType = "Aviso de Obra"
+		}
 		presenters[i] = GetAllReqPresenterRequest{
 			UserID:     req.UserID,
 			PropertyID: req.PropertyID,
@@ -253,7 +289,7 @@ func ToRequestListPresenter(requests []domain.Request) []GetAllReqPresenterReque
 			Documents:          toDocumentRequestListPresenter(req.Documents),
 			StatusName:         req.StatusName,
 			CreatedAt:          CustomTime(req.CreatedAt),
-			Type:               "Aviso de Obra",
+			Type:               requestType,
 		}
 	}
 
@@ -14,7 +14,8 @@ type SuggestionsResponse struct {
 }
 
 type AblOwnershipResponse struct {
-	AblOwnership bool `json:"abl_ownership"`
+	AblOwnership bool                `json:"abl_ownership"`
+	Ownership    *OwnershipPresenter `json:"ownership"`
 }
 
 type VerificationResponse struct {
@@ -26,5 +27,7 @@ type RequestsResponse struct {
 }
 
 type DocumentsResponse struct {
-	Documents []Document `json:"documents"`
+	Documents []Document                `json:"documents"`
+	Ownership *OwnershipPresenter       `json:"ownership,omitempty"`
+	Related   []RelatedRequestPresenter `json:"related_requests"`
 }
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// StatsQueryJson query params de las estadísticas. date_to incluye el día completo
+type StatsQueryJson struct {
+	DateFrom time.Time `form:"date_from" time_format:"2006-01-02"`
+	DateTo   time.Time `form:"date_to" time_format:"2006-01-02"`
+}
+
+type StatsResponse struct {
+	Total            int64                      `json:"total"`
+	ByStatus         []StatCountPresenter       `json:"by_status"`
+	ByRequestType    []StatCountPresenter       `json:"by_request_type"`
+	Weekly           []WeeklyPresenter          `json:"weekly_throughput"`
+	ValidationTime   ValidationTimePresenter    `json:"time_to_validation"`
+	ObservationRates []ObservationRatePresenter `json:"observation_rates"`
+	TopActivities    []StatCountPresenter       `json:"top_activities"`
+}
+
+type StatCountPresenter struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type WeeklyPresenter struct {
+	WeekStart string `json:"week_start"`
+	Filed     int64  `json:"filed"`
+	Validated int64  `json:"validated"`
+}
+
+type ValidationTimePresenter struct {
+	Count       int64   `json:"count"`
+	MedianHours float64 `json:"median_hours"`
+	P90Hours    float64 `json:"p90_hours"`
+}
+
+type ObservationRatePresenter struct {
+	Track            string  `json:"track"`
+	Verifications    int64   `json:"verifications"`
+	Observations     int64   `json:"observations"`
+	RequestsVerified int64   `json:"requests_verified"`
+	RequestsObserved int64   `json:"requests_observed"`
+	Rate             float64 `json:"rate"`
+}
+
+func ToStatsQueryDomain(q *StatsQueryJson) domain.StatsQuery {
+	query := domain.StatsQuery{
+		From: q.DateFrom,
+	}
+	if !q.DateTo.IsZero() {
+		query.To = q.DateTo.AddDate(0, 0, 1)
+	}
+	return query
+}
+
+func ToStatsResponse(stats *domain.Stats) StatsResponse {
+	weekly := make([]WeeklyPresenter, len(stats.Weekly))
+	for i, week := range stats.Weekly {
+		weekly[i] = WeeklyPresenter{
+			WeekStart: week.WeekStart.Format("2006-01-02"),
+			Filed:     week.Filed,
+			Validated: week.Validated,
+		}
+	}
+
+	rates := make([]ObservationRatePresenter, len(stats.ObservationRates))
+	for i, rate := range stats.ObservationRates {
+		rates[i] = ObservationRatePresenter{
+			Track:            string(rate.Track),
+			Verifications:    rate.Verifications,
+			Observations:     rate.Observations,
+			RequestsVerified: rate.RequestsVerified,
+			RequestsObserved: rate.RequestsObserved,
+			Rate:             rate.Rate(),
+		}
+	}
+
+	return StatsResponse{
+		Total:         stats.Total,
+		ByStatus:      toStatCountPresenters(stats.ByStatus),
+		ByRequestType: toStatCountPresenters(stats.ByRequestType),
+		Weekly:        weekly,
+		ValidationTime: ValidationTimePresenter{
+			Count:       stats.ValidationTime.Count,
+			MedianHours: stats.ValidationTime.Median.Hours(),
+			P90Hours:    stats.ValidationTime.P90.Hours(),
+		},
+		ObservationRates: rates,
+		TopActivities:    toStatCountPresenters(stats.TopActivities),
+	}
+}
+
+func toStatCountPresenters(counts []domain.StatCount) []StatCountPresenter {
+	presenters := make([]StatCountPresenter, len(counts))
+	for i, count := range counts {
+		presenters[i] = StatCountPresenter{
+			ID:    count.ID,
+			Name:  count.Name,
+			Count: count.Count,
+		}
+	}
+	return presenters
+}
@@ -0,0 +1,164 @@
+package transport
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// UploadErrorResponse detalla los archivos rechazados, cada uno con el campo del payload que lo contiene
+type UploadErrorResponse struct {
+	Error  string                 `json:"error"`
+	Fields []UploadFieldPresenter `json:"fields"`
+}
+
+type UploadFieldPresenter struct {
+	Field string `json:"field"`
+	Type  int    `json:"type,omitempty"`
+	Error string `json:"error"`
+}
+
+// ToUploadErrorResponse arma la respuesta a partir de los errores de validación. list es el campo del
+// payload con la lista de archivos ("files", "attachments"); vacío cuando el payload es un único archivo
+func ToUploadErrorResponse(errs domain.UploadErrors, list string) UploadErrorResponse {
+	fields := make([]UploadFieldPresenter, len(errs))
+	for i, uploadErr := range errs {
+		fields[i] = UploadFieldPresenter{
+			Field: uploadField(list, uploadErr.Index),
+			Type:  int(uploadErr.Type),
+			Error: uploadErr.Reason,
+		}
+	}
+
+	return UploadErrorResponse{
+		Error:  domain.ErrInvalidUpload.Error(),
+		Fields: fields,
+	}
+}
+
+func uploadField(list string, index int) string {
+	switch {
+	case list == "":
+		return "content"
+	case index == domain.RequestUploadIndex:
+		return list
+	default:
+		return fmt.Sprintf("%s[%d].content", list, index)
+	}
+}
+
+// Headers del protocolo tus
+const (
+	TusResumableHeader   = "Tus-Resumable"
+	TusVersionHeader     = "Tus-Version"
+	TusExtensionHeader   = "Tus-Extension"
+	TusMaxSizeHeader     = "Tus-Max-Size"
+	UploadLengthHeader   = "Upload-Length"
+	UploadDeferHeader    = "Upload-Defer-Length"
+	UploadOffsetHeader   = "Upload-Offset"
+	UploadMetadataHeader = "Upload-Metadata"
+	UploadExpiresHeader  = "Upload-Expires"
+	// TusContentType es el único Content-Type aceptado al enviar un fragmento
+	TusContentType = "application/offset+octet-stream"
+)
+
+var (
+	ErrInvalidUploadLength   = errors.New("invalid Upload-Length header")
+	ErrInvalidUploadOffset   = errors.New("invalid Upload-Offset header")
+	ErrInvalidUploadMetadata = errors.New("invalid Upload-Metadata header")
+	ErrDeferLengthNotAllowed = errors.New("Upload-Defer-Length is not supported")
+)
+
+type UploadPresenter struct {
+	ID        string     `json:"id"`
+	Type      int        `json:"type"`
+	Filename  string     `json:"filename"`
+	Length    int64      `json:"length"`
+	Offset    int64      `json:"offset"`
+	ExpiresAt CustomTime `json:"expires_at"`
+}
+
+func ToUploadPresenter(session *domain.UploadSession) UploadPresenter {
+	return UploadPresenter{
+		ID:        session.ID,
+		Type:      int(session.Type),
+		Filename:  session.Filename,
+		Length:    session.Length,
+		Offset:    session.Offset,
+		ExpiresAt: CustomTime(session.ExpiresAt),
+	}
+}
+
+// ToUploadSessionDomain arma la subida a partir de los headers del pedido de creación. Upload-Metadata
+// lleva pares "clave valor-en-base64" separados por comas; se usan filename y type (tipo de documento)
+func ToUploadSessionDomain(header http.Header) (*domain.UploadSession, error) {
+	if header.Get(UploadDeferHeader) != "" {
+		return nil, ErrDeferLengthNotAllowed
+	}
+
+	length, err := strconv.ParseInt(header.Get(UploadLengthHeader), 10, 64)
+	if err != nil || length < 0 {
+		return nil, ErrInvalidUploadLength
+	}
+
+	metadata, err := parseUploadMetadata(header.Get(UploadMetadataHeader))
+	if err != nil {
+		return nil, err
+	}
+
+	docType, err := strconv.Atoi(metadata["type"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: type", ErrInvalidUploadMetadata)
+	}
+
+	return &domain.UploadSession{
+		Type:     domain.DocumentTypeID(docType),
+		Filename: metadata["filename"],
+		Length:   length,
+	}, nil
+}
+
+// ParseUploadOffset lee el offset desde el que se envía el fragmento
+func ParseUploadOffset(header http.Header) (int64, error) {
+	offset, err := strconv.ParseInt(header.Get(UploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidUploadOffset
+	}
+	return offset, nil
+}
+
+// ToUploadMetadata devuelve el header Upload-Metadata de la subida
+func ToUploadMetadata(session *domain.UploadSession) string {
+	metadata := []string{"type " + base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(int(session.Type))))}
+	if session.Filename != "" {
+		metadata = append(metadata, "filename "+base64.StdEncoding.EncodeToString([]byte(session.Filename)))
+	}
+	return strings.Join(metadata, ",")
+}
+
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		switch len(fields) {
+		case 0:
+			continue
+		case 1:
+			metadata[fields[0]] = ""
+		case 2:
+			value, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidUploadMetadata, fields[0])
+			}
+			metadata[fields[0]] = string(value)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidUploadMetadata, fields[0])
+		}
+	}
+	return metadata, nil
+}
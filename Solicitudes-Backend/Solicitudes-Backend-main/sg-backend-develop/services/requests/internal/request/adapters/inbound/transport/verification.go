@@ -62,6 +62,10 @@ type Document struct {
 	Request      *RequestResume `json:"request,omitempty"`
 	VerifiedBy   string         `json:"verifiedBy,omitempty"`
 	VerifiedDate string         `json:"verifiedDate,omitempty"`
+	DocumentID   int64          `json:"documentId,string,omitempty"`
+	Type         int            `json:"type,omitempty"`
+	Decision     string         `json:"decision,omitempty"`
+	Reason       string         `json:"reason,omitempty"`
 }
 
 type RequestResume struct {
@@ -79,19 +83,47 @@ type DocContent struct {
 }
 
 type VerificationRequestPresenter struct {
-	ID                int64      `json:"id"`
-	VerificationCase  string     `json:"verificationCase,omitempty"`
-	RecordNumber      string     `json:"recordNumber"`
-	RequestType       string     `json:"requestType"`
-	DocumentType      string     `json:"documentType"`
-	DeliveryDate      string     `json:"deliveryDate"`
-	Status            string     `json:"status"`
-	StatusTask        string     `json:"status_task"`
-	StatusProperty    string     `json:"status_property"`
-	RequesterFullName string     `json:"requesterFullName"`
-	RequesterCuil     string     `json:"requesterCuil"`
-	RequesterAddress  string     `json:"requesterAddress"`
-	Documents         []Document `json:"documents,omitempty"`
+	ID                int64         `json:"id"`
+	VerificationCase  string        `json:"verificationCase,omitempty"`
+	RecordNumber      string        `json:"recordNumber"`
+	RequestType       string        `json:"requestType"`
+	DocumentType      string        `json:"documentType"`
+	DeliveryDate      string        `json:"deliveryDate"`
+	Status            string        `json:"status"`
+	StatusTask        string        `json:"status_task"`
+	StatusProperty    string        `json:"status_property"`
+	RequesterFullName string        `json:"requesterFullName"`
+	RequesterCuil     string        `json:"requesterCuil"`
+	RequesterAddress  string        `json:"requesterAddress"`
+	Documents         []Document    `json:"documents,omitempty"`
+	SLA               *SLAPresenter `json:"sla,omitempty"`
+}
+
+// SLAPresenter vencimiento de la etapa en que se encuentra la solicitud
+type SLAPresenter struct {
+	Stage         string     `json:"stage"`
+	StartedAt     CustomTime `json:"started_at"`
+	DueDate       CustomTime `json:"due_date"`
+	RemainingDays int        `json:"remaining_business_days"`
+	State         string     `json:"state"`
+	Overdue       bool       `json:"overdue"`
+	AtRisk        bool       `json:"at_risk"`
+}
+
+func ToSLAPresenter(sla *domain.SLA) *SLAPresenter {
+	if sla == nil {
+		return nil
+	}
+
+	return &SLAPresenter{
+		Stage:         string(sla.Stage),
+		StartedAt:     CustomTime(sla.StartedAt),
+		DueDate:       CustomTime(sla.DueDate),
+		RemainingDays: sla.RemainingDays,
+		State:         string(sla.State),
+		Overdue:       sla.State == domain.SLAStateOverdue,
+		AtRisk:        sla.State == domain.SLAStateAtRisk,
+	}
 }
 
 func ToVerificationRequestPresenter(req *domain.Verification) *VerificationRequestPresenter {
@@ -136,6 +168,7 @@ func ToVerificationListPresenter(list []domain.Verification) []VerificationReque
 			RequesterFullName: model.RequesterFullName,
 			RequesterCuil:     model.RequesterCuil,
 			RequesterAddress:  model.RequesterAddress,
+			SLA:               ToSLAPresenter(model.SLA),
 		}
 	}
 	return verifications
@@ -182,6 +215,26 @@ func ToDocumentListPresenter(request *domain.Request, list []domain.Document, co
 			GedoCode:     model.GedoCode,
 			VerifiedBy:   request.VerifyBy,
 			VerifiedDate: request.VerifyDate.Format("2006-01-02 15:04:05"),
+			DocumentID:   model.ID,
+			Type:         model.Type,
+			Decision:     string(model.Decision()),
+			Reason:       model.Observations,
+		}
+	}
+	return docs
+}
+
+// ToRejectedDocumentList arma la lista de documentos que el ciudadano tiene que volver a subir
+func ToRejectedDocumentList(list []domain.Document) []Document {
+	docs := make([]Document, len(list))
+	for i, model := range list {
+		docs[i] = Document{
+			ID:         int64(i + 1),
+			Title:      model.Title,
+			DocumentID: model.ID,
+			Type:       model.Type,
+			Decision:   string(model.Decision()),
+			Reason:     model.Observations,
 		}
 	}
 	return docs
@@ -0,0 +1,148 @@
+package transport
+
+import (
+	"encoding/json"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// WebhookJson alta o modificación de una suscripción. Sin event_types recibe todos los eventos; sin
+// secret se genera uno en el alta y se conserva el actual en la modificación
+type WebhookJson struct {
+	Name       string   `json:"name" binding:"required"`
+	URL        string   `json:"url" binding:"required"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	Active     *bool    `json:"active"`
+}
+
+type WebhooksResponse struct {
+	Webhooks   []WebhookPresenter `json:"webhooks"`
+	EventTypes []string           `json:"event_types"` // eventos a los que se puede suscribir
+}
+
+type WebhookPresenter struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	URL        string     `json:"url"`
+	Secret     string     `json:"secret,omitempty"`
+	EventTypes []string   `json:"event_types"`
+	Active     bool       `json:"active"`
+	CreatedAt  CustomTime `json:"created_at"`
+	UpdatedAt  CustomTime `json:"updated_at"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryPresenter `json:"deliveries"`
+}
+
+type WebhookDeliveryPresenter struct {
+	ID             int64           `json:"id"`
+	RequestID      int64           `json:"request_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *CustomTime     `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *CustomTime     `json:"delivered_at,omitempty"`
+	RedeliveryOf   int64           `json:"redelivery_of,omitempty"`
+	CreatedAt      CustomTime      `json:"created_at"`
+}
+
+func ToWebhookDomain(req WebhookJson) *domain.WebhookSubscription {
+	eventTypes := make([]domain.TimelineEventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = domain.TimelineEventType(eventType)
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &domain.WebhookSubscription{
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: eventTypes,
+		Active:     active,
+	}
+}
+
+// ToWebhookPresenter no incluye el secreto, que solo se muestra al crear la suscripción
+func ToWebhookPresenter(sub *domain.WebhookSubscription) WebhookPresenter {
+	eventTypes := make([]string, len(sub.EventTypes))
+	for i, eventType := range sub.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return WebhookPresenter{
+		ID:         sub.ID,
+		Name:       sub.Name,
+		URL:        sub.URL,
+		EventTypes: eventTypes,
+		Active:     sub.Active,
+		CreatedAt:  CustomTime(sub.CreatedAt),
+		UpdatedAt:  CustomTime(sub.UpdatedAt),
+	}
+}
+
+func ToCreatedWebhookPresenter(sub *domain.WebhookSubscription) WebhookPresenter {
+	presenter := ToWebhookPresenter(sub)
+	presenter.Secret = sub.Secret
+	return presenter
+}
+
+func ToWebhooksResponse(subs []domain.WebhookSubscription) WebhooksResponse {
+	response := WebhooksResponse{
+		Webhooks:   make([]WebhookPresenter, len(subs)),
+		EventTypes: make([]string, len(domain.WebhookEventTypes)),
+	}
+	for i := range subs {
+		response.Webhooks[i] = ToWebhookPresenter(&subs[i])
+	}
+	for i, eventType := range domain.WebhookEventTypes {
+		response.EventTypes[i] = string(eventType)
+	}
+
+	return response
+}
+
+func ToWebhookDeliveryPresenter(delivery *domain.WebhookDelivery) WebhookDeliveryPresenter {
+	presenter := WebhookDeliveryPresenter{
+		ID:             delivery.ID,
+		RequestID:      delivery.RequestID,
+		EventType:      string(delivery.EventType),
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		CreatedAt:      CustomTime(delivery.CreatedAt),
+	}
+
+	if delivery.Status == domain.WebhookDeliveryPending {
+		nextAttemptAt := CustomTime(delivery.NextAttemptAt)
+		presenter.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.DeliveredAt != nil {
+		deliveredAt := CustomTime(*delivery.DeliveredAt)
+		presenter.DeliveredAt = &deliveredAt
+	}
+
+	return presenter
+}
+
+func ToWebhookDeliveriesResponse(deliveries []domain.WebhookDelivery) WebhookDeliveriesResponse {
+	response := WebhookDeliveriesResponse{
+		Deliveries: make([]WebhookDeliveryPresenter, len(deliveries)),
+	}
+	for i := range deliveries {
+		response.Deliveries[i] = ToWebhookDeliveryPresenter(&deliveries[i])
+	}
+
+	return response
+}
@@ -0,0 +1,5 @@
+package transport
+
+type WithdrawalJson struct {
+	Reason string `json:"reason"`
+}
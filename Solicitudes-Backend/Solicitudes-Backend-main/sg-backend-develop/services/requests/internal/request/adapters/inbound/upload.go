@@ -0,0 +1,201 @@
+package inbound
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	sdkmwr "github.com/teamcubation/sg-backend/pkg/rest/middlewares/gin"
+
+	config "github.com/teamcubation/sg-backend/services/requests/internal/config"
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/inbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// Subidas reanudables según el protocolo tus 1.0.0, con las extensiones creation, termination y
+// expiration. El cliente crea la subida con POST, envía los fragmentos con PATCH desde el offset que
+// devuelve HEAD y usa el id de la subida en el alta o el reenvío de la solicitud
+
+// uploadErrorStatus traduce los errores de las subidas a códigos HTTP
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrUploadNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrUploadOffsetMismatch):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrUploadTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrInvalidUploadSession), errors.Is(err, domain.ErrInvalidUpload):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// GetUploadCapabilities informa la versión, las extensiones y el tamaño máximo soportados
+func (h *GinHandler) GetUploadCapabilities(c *gin.Context) {
+	c.Header(transport.TusResumableHeader, domain.TusVersion)
+	c.Header(transport.TusVersionHeader, domain.TusVersion)
+	c.Header(transport.TusExtensionHeader, strings.Join(domain.TusExtensions, ","))
+	c.Header(transport.TusMaxSizeHeader, strconv.FormatInt(config.GetUploadConfig().MaxFileSize, 10))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *GinHandler) CreateUpload(c *gin.Context) {
+	if !tusResumable(c) {
+		return
+	}
+
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	session, err := transport.ToUploadSessionDomain(c.Request.Header)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.CreateUpload(c, cuil, session); err != nil {
+		c.JSON(uploadErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+session.ID)
+	c.Header(transport.UploadExpiresHeader, uploadExpires(session.ExpiresAt))
+	c.JSON(http.StatusCreated, transport.ToUploadPresenter(session))
+}
+
+// HeadUpload devuelve el offset desde el que el cliente tiene que retomar la subida
+func (h *GinHandler) HeadUpload(c *gin.Context) {
+	if !tusResumable(c) {
+		return
+	}
+
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.Status(http.StatusUnauthorized)
+		return
+	}
+
+	session, err := h.ucs.GetUpload(c, c.Param("upload_id"), cuil)
+	if err != nil {
+		c.Status(uploadErrorStatus(err))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header(transport.UploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+	c.Header(transport.UploadLengthHeader, strconv.FormatInt(session.Length, 10))
+	c.Header(transport.UploadMetadataHeader, transport.ToUploadMetadata(session))
+	c.Header(transport.UploadExpiresHeader, uploadExpires(session.ExpiresAt))
+	c.Status(http.StatusOK)
+}
+
+// PatchUpload guarda el fragmento enviado desde Upload-Offset. Al completar la subida se valida el
+// archivo y, si no es válido, se descarta y se responde con el detalle como en el alta
+func (h *GinHandler) PatchUpload(c *gin.Context) {
+	if !tusResumable(c) {
+		return
+	}
+
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if c.ContentType() != transport.TusContentType {
+		c.JSON(http.StatusUnsupportedMediaType, transport.ErrorResponse{
+			Error: "Content-Type must be " + transport.TusContentType,
+		})
+		return
+	}
+
+	offset, err := transport.ParseUploadOffset(c.Request.Header)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Un fragmento nunca supera el tamaño máximo de un archivo; el byte extra permite detectar el exceso
+	chunk, err := io.ReadAll(io.LimitReader(c.Request.Body, config.GetUploadConfig().MaxFileSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	session, err := h.ucs.AppendUpload(c, c.Param("upload_id"), cuil, offset, chunk)
+	if err != nil {
+		if uploadErrorResponse(c, err, "") {
+			return
+		}
+		c.JSON(uploadErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.Header(transport.UploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+	c.Header(transport.UploadExpiresHeader, uploadExpires(session.ExpiresAt))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *GinHandler) DeleteUpload(c *gin.Context) {
+	if !tusResumable(c) {
+		return
+	}
+
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	if err := h.ucs.DeleteUpload(c, c.Param("upload_id"), cuil); err != nil {
+		c.JSON(uploadErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// tusResumable agrega el header Tus-Resumable a la respuesta y rechaza los clientes de otra versión
+func tusResumable(c *gin.Context) bool {
+	c.Header(transport.TusResumableHeader, domain.TusVersion)
+
+	if c.GetHeader(transport.TusResumableHeader) != domain.TusVersion {
+		c.Header(transport.TusVersionHeader, domain.TusVersion)
+		c.AbortWithStatus(http.StatusPreconditionFailed)
+		return false
+	}
+
+	return true
+}
+
+// uploadExpires formatea el vencimiento de la subida como lo espera el header Upload-Expires
+func uploadExpires(t time.Time) string {
+	return t.UTC().Format(http.TimeFormat)
+}
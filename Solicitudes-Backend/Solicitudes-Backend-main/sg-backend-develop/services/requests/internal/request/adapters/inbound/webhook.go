@@ -0,0 +1,195 @@
+package inbound
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	sdkmwr "github.com/teamcubation/sg-backend/pkg/rest/middlewares/gin"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/inbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// webhookErrorStatus traduce los errores de las suscripciones a webhooks a códigos HTTP
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound), errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *GinHandler) CreateWebhook(c *gin.Context) {
+	cuil, err := sdkmwr.ExtractClaim(c, "sub", "")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var req transport.WebhookJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	sub := transport.ToWebhookDomain(req)
+	if err := h.ucs.CreateWebhook(c, cuil, sub); err != nil {
+		c.JSON(webhookErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// El secreto se muestra una sola vez: los reintentos con la misma Idempotency-Key reciben la suscripción sin él
+	setIdempotentResponse(c, transport.ToWebhookPresenter(sub))
+	c.JSON(http.StatusCreated, transport.ToCreatedWebhookPresenter(sub))
+}
+
+func (h *GinHandler) GetWebhooks(c *gin.Context) {
+	subs, err := h.ucs.GetWebhooks(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToWebhooksResponse(subs))
+}
+
+func (h *GinHandler) GetWebhook(c *gin.Context) {
+	id, ok := webhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	sub, err := h.ucs.GetWebhook(c, id)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToWebhookPresenter(sub))
+}
+
+func (h *GinHandler) UpdateWebhook(c *gin.Context) {
+	id, ok := webhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req transport.WebhookJson
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidPayload.Error(),
+		})
+		return
+	}
+
+	sub := transport.ToWebhookDomain(req)
+	sub.ID = id
+	if err := h.ucs.UpdateWebhook(c, sub); err != nil {
+		c.JSON(webhookErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToWebhookPresenter(sub))
+}
+
+func (h *GinHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := webhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.ucs.DeleteWebhook(c, id); err != nil {
+		c.JSON(webhookErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.MessageResponse{
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// GetWebhookDeliveries devuelve el registro de envíos de la suscripción. Acepta los filtros status y limit
+func (h *GinHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, ok := webhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	query := domain.WebhookDeliveryQuery{
+		SubscriptionID: id,
+		Status:         domain.WebhookDeliveryStatus(c.Query("status")),
+	}
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+				Error: transport.ErrInvalidQueryParam.Error(),
+			})
+			return
+		}
+		query.Limit = value
+	}
+
+	deliveries, err := h.ucs.GetWebhookDeliveries(c, query)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transport.ToWebhookDeliveriesResponse(deliveries))
+}
+
+// RedeliverWebhook encola otra vez un envío de la suscripción, que se entrega como un envío nuevo
+func (h *GinHandler) RedeliverWebhook(c *gin.Context) {
+	id, ok := webhookID(c, "id")
+	if !ok {
+		return
+	}
+
+	deliveryID, ok := webhookID(c, "deliveryId")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.ucs.RedeliverWebhook(c, id, deliveryID)
+	if err != nil {
+		c.JSON(webhookErrorStatus(err), transport.ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, transport.ToWebhookDeliveryPresenter(delivery))
+}
+
+func webhookID(c *gin.Context, param string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, transport.ErrorResponse{
+			Error: transport.ErrInvalidWebhookID.Error(),
+		})
+		return 0, false
+	}
+	return id, true
+}
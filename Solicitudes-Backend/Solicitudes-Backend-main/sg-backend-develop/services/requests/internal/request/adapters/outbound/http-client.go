@@ -217,6 +217,51 @@ func (h *HttpClient) SendValidationDocument(ctx context.Context, code, content,
 	return nil
 }
 
+func (h *HttpClient) SendWithdrawalDocument(ctx context.Context, code, content, name string) error {
+	jsonBody, err := json.Marshal(docContent{
+		Content:   content,
+		Reference: "Desistimiento de la solicitud",
+		Type:      int(domain.DocumentTypeWithdrawal),
+		Username:  name,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling json: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(
+		"POST",
+		fmt.Sprintf("%s/api/v1/file-manager/record/%s/documents", os.Getenv("FILE_MANAGER_HOST"), code),
+		bytes.NewBuffer(jsonBody),
+	)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("error unmarshaling response: %w", err)
+	}
+
+	return nil
+}
+
 func (h *HttpClient) SendEmail(ctx context.Context, code, email string) error {
 	req := transport.EmailRequestPayload{
 		Code:  code,
@@ -260,13 +305,17 @@ type EmailRequestPayload struct {
 	Code         string `json:"code" binding:"required"`
 	Email        string `json:"email"`
 	Observations string `json:"observations"`
+	RequestType  string `json:"request_type,omitempty"`
 }
 
-func (h *HttpClient) sendEmail(ctx context.Context, endpoint, code, email, observations string) error {
+// sendEmail pide al servicio de mailing el envío del email. requestType es el nombre del tipo de
+// la solicitud que se usa en el asunto; vacío en los emails que no lo incluyen
+func (h *HttpClient) sendEmail(ctx context.Context, endpoint, code, email, observations, requestType string) error {
 	req := EmailRequestPayload{
 		Code:         code,
 		Email:        email,
 		Observations: observations,
+		RequestType:  requestType,
 	}
 
 	jsonBody, err := json.Marshal(&req)
@@ -302,13 +351,53 @@ func (h *HttpClient) sendEmail(ctx context.Context, endpoint, code, email, obser
 }
 
 func (h *HttpClient) SendEmailUpdateRequest(ctx context.Context, code, email string) error {
-	return h.sendEmail(ctx, "/api/v1/mailing/update-request", code, email, "")
+	return h.sendEmail(ctx, "/api/v1/mailing/update-request", code, email, "", "")
 }
 
 func (h *HttpClient) SendEmailUpdate(ctx context.Context, code, email, observations string) error {
-	return h.sendEmail(ctx, "/api/v1/mailing/update-request-code", code, email, observations)
+	return h.sendEmail(ctx, "/api/v1/mailing/update-request-code", code, email, observations, "")
 }
 
 func (h *HttpClient) SendEmailValidateRequest(ctx context.Context, code, email string) error {
-	return h.sendEmail(ctx, "/api/v1/mailing/validate-request", code, email, "")
+	return h.sendEmail(ctx, "/api/v1/mailing/validate-request", code, email, "", "")
+}
+
+func (h *HttpClient) SendEmailNewMessage(ctx context.Context, code, email, content, requestType string) error {
+	return h.sendEmail(ctx, "/api/v1/mailing/new-message", code, email, content, requestType)
+}
+
+func (h *HttpClient) SendEmailWithdrawn(ctx context.Context, code, email, reason, requestType string) error {
+	return h.sendEmail(ctx, "/api/v1/mailing/withdrawn-request", code, email, reason, requestType)
+}
+
+// SendWebhook envía el evento firmado al suscriptor y devuelve el código de estado de la respuesta. Cualquier
+// respuesta 2xx se considera entregada
+func (h *HttpClient) SendWebhook(ctx context.Context, url string, headers map[string]string, payload []byte) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Solo se guarda el comienzo de la respuesta en el registro de envíos
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("error reading response: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return resp.StatusCode, nil
 }
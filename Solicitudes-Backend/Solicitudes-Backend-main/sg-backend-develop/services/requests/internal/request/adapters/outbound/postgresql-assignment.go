@@ -0,0 +1,172 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/lib/pq"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// ClaimRequest asigna los circuitos de la solicitud al verificador por el tiempo de la asignación.
+// Si otro verificador tiene una asignación vigente sobre alguno de los circuitos se rechaza,
+// salvo que se fuerce la reasignación
+func (r *PostgreSQL) ClaimRequest(ctx context.Context, claim *domain.Claim) ([]domain.Assignment, error) {
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	statuses, err := lockRequestStatuses(ctx, tx, claim.FileNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	canVerify, err := userHasPermission(ctx, tx, claim.UserID, domain.PermissionVerify)
+	if err != nil {
+		return nil, err
+	}
+	if !canVerify {
+		return nil, fmt.Errorf("%w: user %d", domain.ErrAssigneeNotVerifier, claim.UserID)
+	}
+
+	// Si el verificador renueva su propia asignación se conserva la fecha en que la tomó
+	const query = `
+		INSERT INTO request_assignments (request_id, track, user_id, assigned_by, claimed_at, expires_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP + make_interval(secs => $5))
+		ON CONFLICT (request_id, track) DO UPDATE
+		SET user_id = EXCLUDED.user_id,
+			assigned_by = EXCLUDED.assigned_by,
+			claimed_at = CASE
+				WHEN request_assignments.user_id = EXCLUDED.user_id AND request_assignments.expires_at > CURRENT_TIMESTAMP
+				THEN request_assignments.claimed_at
+				ELSE EXCLUDED.claimed_at
+			END,
+			expires_at = EXCLUDED.expires_at
+		RETURNING claimed_at, expires_at`
+
+	assignments := make([]domain.Assignment, 0, len(claim.Tracks))
+	for _, track := range claim.Tracks {
+		if statuses.Of(track) != domain.RequestStatusPending {
+			return nil, fmt.Errorf("%w: %s track of request %s", domain.ErrTrackNotPending, track, claim.FileNumber)
+		}
+
+		if !claim.Force {
+			if err := checkAssignment(ctx, tx, statuses.RequestID, track, claim.UserID); err != nil {
+				return nil, err
+			}
+		}
+
+		assignment := domain.Assignment{
+			RequestID:  statuses.RequestID,
+			FileNumber: claim.FileNumber,
+			Track:      track,
+			UserID:     claim.UserID,
+			AssignedBy: claim.AssignedBy,
+		}
+		err := tx.QueryRow(ctx, query,
+			statuses.RequestID,
+			string(track),
+			claim.UserID,
+			claim.AssignedBy,
+			claim.Lease.Seconds(),
+		).Scan(&assignment.ClaimedAt, &assignment.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("error saving assignment: %w", err)
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return assignments, nil
+}
+
+// ReleaseRequest libera las asignaciones que el verificador tiene sobre los circuitos de la solicitud.
+// Las asignaciones de otros verificadores no se modifican
+func (r *PostgreSQL) ReleaseRequest(ctx context.Context, fileNumber string, tracks []domain.StatusTrack, userID int64) error {
+	const query = `
+		DELETE FROM request_assignments ra
+		USING requests r
+		WHERE ra.request_id = r.id
+		AND r.file_number = $1
+		AND ra.track = ANY($2)
+		AND ra.user_id = $3`
+
+	names := make([]string, len(tracks))
+	for i, track := range tracks {
+		names[i] = string(track)
+	}
+
+	if _, err := r.repository.Pool().Exec(ctx, query, fileNumber, pq.Array(names), userID); err != nil {
+		return fmt.Errorf("error releasing assignment: %w", err)
+	}
+
+	return nil
+}
+
+// checkAssignment rechaza la operación si otro verificador tiene una asignación vigente sobre el circuito.
+// Los circuitos sin asignar o con la asignación vencida se pueden operar libremente
+func checkAssignment(ctx context.Context, tx pgx.Tx, requestID int64, track domain.StatusTrack, userID int64) error {
+	const query = `
+		SELECT user_id
+		FROM request_assignments
+		WHERE request_id = $1
+		AND track = $2
+		AND expires_at > CURRENT_TIMESTAMP`
+
+	var assignee int64
+	err := tx.QueryRow(ctx, query, requestID, string(track)).Scan(&assignee)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("error fetching assignment: %w", err)
+	}
+
+	if assignee != userID {
+		return fmt.Errorf("%w: %s track of request %d", domain.ErrAssignmentConflict, track, requestID)
+	}
+
+	return nil
+}
+
+// deleteAssignment libera el circuito una vez verificado
+func deleteAssignment(ctx context.Context, tx pgx.Tx, requestID int64, track domain.StatusTrack) error {
+	const query = `DELETE FROM request_assignments WHERE request_id = $1 AND track = $2`
+
+	if _, err := tx.Exec(ctx, query, requestID, string(track)); err != nil {
+		return fmt.Errorf("error releasing assignment: %w", err)
+	}
+
+	return nil
+}
+
+// userHasPermission indica si alguno de los roles del usuario le otorga el permiso
+func userHasPermission(ctx context.Context, tx pgx.Tx, userID int64, permission string) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM users u
+			JOIN user_roles ur ON ur.user_id = u.id
+			JOIN role_permissions rp ON rp.role_id = ur.role_id
+			JOIN permissions pe ON pe.id = rp.permission_id
+			WHERE u.id = $1
+			AND u.deleted_at IS NULL
+			AND pe.name = $2
+		)`
+
+	var exists bool
+	if err := tx.QueryRow(ctx, query, userID, permission).Scan(&exists); err != nil {
+		return false, fmt.Errorf("error fetching user permissions: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,37 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// updateDocumentDecisions guarda la decisión del verificador sobre cada documento en la misma
+// transacción que la verificación del circuito. El motivo del rechazo queda en observations. Solo se
+// decide sobre la versión vigente; la que sube el ciudadano al reenviar empieza pendiente
+func updateDocumentDecisions(ctx context.Context, tx pgx.Tx, fileNumber string, decisions []domain.DocumentDecision) error {
+	const query = `
+		UPDATE documents
+		SET is_verified = $1, observations = NULLIF($2, ''), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND code = $4 AND NOT superseded`
+
+	for _, decision := range decisions {
+		tag, err := tx.Exec(ctx, query,
+			decision.Status == domain.DocumentDecisionAccepted,
+			decision.Reason,
+			decision.DocumentID,
+			fileNumber,
+		)
+		if err != nil {
+			return fmt.Errorf("error updating document decision: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("%w: document %d is not part of the request", domain.ErrInvalidDocumentDecision, decision.DocumentID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+const documentVersionColumns = `
+		documents.id,
+		documents.document_type_id,
+		document_types.description,
+		documents.file_id,
+		documents.filename,
+		documents.version,
+		documents.uploaded_by,
+		documents.superseded,
+		COALESCE(documents.is_verified, false),
+		documents.observations,
+		documents.created_at`
+
+// GetDocumentVersions devuelve las versiones de un tipo de documento del expediente, de la más nueva
+// a la más vieja. No incluye el contenido de los archivos
+func (r *PostgreSQL) GetDocumentVersions(ctx context.Context, fileNumber string, docType domain.DocumentTypeID) ([]domain.DocumentVersion, error) {
+	query := `
+		SELECT` + documentVersionColumns + `
+		FROM documents
+		LEFT JOIN document_types ON document_types.id = documents.document_type_id
+		WHERE documents.code = $1 AND documents.document_type_id = $2
+		ORDER BY documents.version DESC, documents.id DESC`
+
+	rows, err := r.repository.Pool().Query(ctx, query, fileNumber, int(docType))
+	if err != nil {
+		return nil, fmt.Errorf("error querying document versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []domain.DocumentVersion{}
+	for rows.Next() {
+		version, err := scanDocumentVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return versions, nil
+}
+
+// GetDocumentVersion devuelve una versión de un tipo de documento del expediente con su contenido
+func (r *PostgreSQL) GetDocumentVersion(ctx context.Context, fileNumber string, docType domain.DocumentTypeID, version int) (*domain.DocumentVersion, error) {
+	query := `
+		SELECT` + documentVersionColumns + `,
+		documents.content
+		FROM documents
+		LEFT JOIN document_types ON document_types.id = documents.document_type_id
+		WHERE documents.code = $1 AND documents.document_type_id = $2 AND documents.version = $3
+		ORDER BY documents.id DESC
+		LIMIT 1`
+
+	var content sql.NullString
+	documentVersion, err := scanDocumentVersion(r.repository.Pool().QueryRow(ctx, query, fileNumber, int(docType), version), &content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: type %d version %d of %s", domain.ErrDocumentVersionNotFound, docType, version, fileNumber)
+		}
+		return nil, err
+	}
+	documentVersion.Content = content.String
+
+	return &documentVersion, nil
+}
+
+// scanDocumentVersion lee las columnas de documentVersionColumns seguidas de las columnas extra
+func scanDocumentVersion(row pgx.Row, extra ...any) (domain.DocumentVersion, error) {
+	var model transport.DocumentVersionDataModel
+	dest := append([]any{
+		&model.ID,
+		&model.Type,
+		&model.Description,
+		&model.FileID,
+		&model.Filename,
+		&model.Version,
+		&model.UploadedBy,
+		&model.Superseded,
+		&model.Verified,
+		&model.Observations,
+		&model.CreatedAt,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.DocumentVersion{}, err
+		}
+		return domain.DocumentVersion{}, fmt.Errorf("error scanning document version: %w", err)
+	}
+
+	return transport.ToDocumentVersionDomain(&model), nil
+}
@@ -0,0 +1,420 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/lib/pq"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// CreateDraft guarda el borrador como una solicitud en estado Draft junto con sus archivos
+func (r *PostgreSQL) CreateDraft(ctx context.Context, draft *domain.Draft) error {
+	data, err := transport.ToDraftData(draft.Fields)
+	if err != nil {
+		return fmt.Errorf("error marshaling draft data: %w", err)
+	}
+
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		INSERT INTO requests (user_id, request_type_id, status_id, draft_data, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRow(ctx, query,
+		draft.UserID,
+		domain.DefaultRequestTypeID,
+		int(domain.RequestStatusDraft),
+		data,
+	).Scan(&draft.ID, &draft.CreatedAt, &draft.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%w: %v", transport.ErrCreateRequest, err)
+	}
+
+	if err := upsertDraftDocuments(ctx, tx, draft.ID, draft.Documents); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// GetDrafts obtiene los borradores del usuario, del más reciente al más antiguo, sin el contenido de los archivos
+func (r *PostgreSQL) GetDrafts(ctx context.Context, userID int64) ([]domain.Draft, error) {
+	const query = `
+		SELECT id, user_id, draft_data, created_at, updated_at
+		FROM requests
+		WHERE user_id = $1 AND status_id = $2
+		ORDER BY updated_at DESC`
+
+	rows, err := r.repository.Pool().Query(ctx, query, userID, int(domain.RequestStatusDraft))
+	if err != nil {
+		return nil, fmt.Errorf("error querying drafts: %w", err)
+	}
+	defer rows.Close()
+
+	drafts := []domain.Draft{}
+	positions := make(map[int64]int)
+	for rows.Next() {
+		var model transport.DraftDataModel
+		if err := rows.Scan(&model.ID, &model.UserID, &model.DraftData, &model.CreatedAt, &model.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning draft: %w", err)
+		}
+
+		draft, err := transport.ToDraftDomain(&model)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding draft %d: %w", model.ID, err)
+		}
+		positions[draft.ID] = len(drafts)
+		drafts = append(drafts, draft)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(drafts) == 0 {
+		return drafts, nil
+	}
+
+	ids := make([]int64, len(drafts))
+	for i, draft := range drafts {
+		ids[i] = draft.ID
+	}
+
+	documents, err := r.selectDraftDocuments(ctx, false, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, doc := range documents {
+		i := positions[doc.RequestID]
+		drafts[i].Documents = append(drafts[i].Documents, transport.ToDraftDocumentDomain(&doc))
+	}
+
+	return drafts, nil
+}
+
+// GetDraft obtiene el borrador del usuario, sin el contenido de los archivos
+func (r *PostgreSQL) GetDraft(ctx context.Context, id, userID int64) (*domain.Draft, error) {
+	const query = `
+		SELECT id, user_id, draft_data, created_at, updated_at
+		FROM requests
+		WHERE id = $1 AND user_id = $2 AND status_id = $3`
+
+	var model transport.DraftDataModel
+	err := r.repository.Pool().QueryRow(ctx, query, id, userID, int(domain.RequestStatusDraft)).Scan(
+		&model.ID,
+		&model.UserID,
+		&model.DraftData,
+		&model.CreatedAt,
+		&model.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", domain.ErrDraftNotFound, id)
+		}
+		return nil, fmt.Errorf("error getting draft: %w", err)
+	}
+
+	draft, err := transport.ToDraftDomain(&model)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding draft %d: %w", id, err)
+	}
+
+	documents, err := r.selectDraftDocuments(ctx, false, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, doc := range documents {
+		draft.Documents = append(draft.Documents, transport.ToDraftDocumentDomain(&doc))
+	}
+
+	return &draft, nil
+}
+
+// GetDraftDocuments obtiene los archivos del borrador con su contenido
+func (r *PostgreSQL) GetDraftDocuments(ctx context.Context, id, userID int64) ([]domain.DraftDocument, error) {
+	if _, err := r.GetDraft(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	documents, err := r.selectDraftDocuments(ctx, true, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.DraftDocument, len(documents))
+	for i, doc := range documents {
+		result[i] = transport.ToDraftDocumentDomain(&doc)
+	}
+
+	return result, nil
+}
+
+// UpdateDraft combina los campos recibidos con los ya guardados y reemplaza los archivos del mismo tipo
+func (r *PostgreSQL) UpdateDraft(ctx context.Context, draft *domain.Draft) error {
+	data, err := transport.ToDraftData(draft.Fields)
+	if err != nil {
+		return fmt.Errorf("error marshaling draft data: %w", err)
+	}
+
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		UPDATE requests
+		SET draft_data = draft_data || $3::jsonb, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND status_id = $4
+		RETURNING created_at, updated_at`
+
+	err = tx.QueryRow(ctx, query, draft.ID, draft.UserID, data, int(domain.RequestStatusDraft)).Scan(&draft.CreatedAt, &draft.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %d", domain.ErrDraftNotFound, draft.ID)
+		}
+		return fmt.Errorf("error updating draft: %w", err)
+	}
+
+	if err := upsertDraftDocuments(ctx, tx, draft.ID, draft.Documents); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteDraftDocument quita del borrador el archivo del tipo indicado
+func (r *PostgreSQL) DeleteDraftDocument(ctx context.Context, id, userID int64, docType domain.DocumentTypeID) error {
+	const query = `
+		WITH draft AS (
+			UPDATE requests
+			SET updated_at = CURRENT_TIMESTAMP
+			WHERE id = $1 AND user_id = $2 AND status_id = $3
+			RETURNING id
+		)
+		DELETE FROM draft_documents
+		WHERE request_id = (SELECT id FROM draft) AND document_type_id = $4`
+
+	tag, err := r.repository.Pool().Exec(ctx, query, id, userID, int(domain.RequestStatusDraft), int(docType))
+	if err != nil {
+		return fmt.Errorf("error deleting draft document: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: document %d of draft %d", domain.ErrDraftNotFound, docType, id)
+	}
+
+	return nil
+}
+
+// DeleteDraft descarta el borrador del usuario. Los archivos se eliminan en cascada
+func (r *PostgreSQL) DeleteDraft(ctx context.Context, id, userID int64) error {
+	const query = `
+		DELETE FROM requests
+		WHERE id = $1 AND user_id = $2 AND status_id = $3`
+
+	tag, err := r.repository.Pool().Exec(ctx, query, id, userID, int(domain.RequestStatusDraft))
+	if err != nil {
+		return fmt.Errorf("error deleting draft: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrDraftNotFound, id)
+	}
+
+	return nil
+}
+
+// SubmitDraft presenta el borrador: completa la solicitud con los datos del formulario, la pasa a Processing
+// y registra los mensajes de outbox para generar el expediente, igual que el alta de una solicitud
+func (r *PostgreSQL) SubmitDraft(ctx context.Context, req *domain.Request, msgs ...domain.OutboxMessage) error {
+	if req == nil {
+		return fmt.Errorf("nil request")
+	}
+
+	reqDataModel := transport.ToCreateRequestDataModel(req)
+
+	requestType, err := r.GetRequestType(ctx, reqDataModel.RequestTypeID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	statuses, err := lockRequestStatusesByID(ctx, tx, req.ID)
+	if err != nil {
+		if errors.Is(err, transport.ErrUpdateRequest) {
+			return fmt.Errorf("%w: %d", domain.ErrDraftNotFound, req.ID)
+		}
+		return err
+	}
+
+	if statuses.Global != domain.RequestStatusDraft {
+		return fmt.Errorf("%w: %d", domain.ErrDraftNotFound, req.ID)
+	}
+
+	change, err := statuses.Transition(domain.StatusTrackGlobal, domain.RequestStatusDraft, domain.RequestStatusProcessing, req.UserID, "")
+	if err != nil {
+		return err
+	}
+
+	// Los circuitos que el tipo de trámite no utiliza quedan aprobados
+	statusTasks, statusProperty := requestType.InitialTrackStatuses()
+
+	const query = `
+		UPDATE requests SET
+			user_type = $3,
+			request_type_id = $4,
+			property_id = $5,
+			status_id = $6,
+			description = $7,
+			abl_debt = $8,
+			estimated_time = $9,
+			insurance = $10,
+			selected_activities = $11,
+			form_data = $12,
+			status_id_tasks = $13,
+			status_id_property = $14,
+			draft_data = '{}',
+			created_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2`
+
+	tag, err := tx.Exec(ctx, query,
+		req.ID,
+		req.UserID,
+		reqDataModel.UserType,
+		reqDataModel.RequestTypeID,
+		reqDataModel.PropertyID,
+		int(domain.RequestStatusProcessing),
+		reqDataModel.Description,
+		reqDataModel.ABLDebt,
+		reqDataModel.EstimatedTime,
+		reqDataModel.Insurance,
+		pq.Array(reqDataModel.SelectedActivities),
+		reqDataModel.FormData,
+		int(statusTasks),
+		int(statusProperty),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", transport.ErrCreateRequest, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrDraftNotFound, req.ID)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM draft_documents WHERE request_id = $1`, req.ID); err != nil {
+		return fmt.Errorf("error deleting draft documents: %w", err)
+	}
+
+	if err := insertRequestFiles(ctx, tx, req.ID, req.Documents); err != nil {
+		return err
+	}
+
+	if err := insertWorkflowHistory(ctx, tx, req.ID, change); err != nil {
+		return err
+	}
+
+	if err := insertOutboxMessages(ctx, tx, req.ID, msgs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteStaleDrafts elimina los borradores que no se modificaron desde before y devuelve cuántos se eliminaron
+func (r *PostgreSQL) DeleteStaleDrafts(ctx context.Context, before time.Time) (int64, error) {
+	const query = `
+		DELETE FROM requests
+		WHERE status_id = $1 AND updated_at < $2`
+
+	tag, err := r.repository.Pool().Exec(ctx, query, int(domain.RequestStatusDraft), before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting stale drafts: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// helpers
+
+func (r *PostgreSQL) selectDraftDocuments(ctx context.Context, withContent bool, ids ...int64) ([]transport.DraftDocumentDataModel, error) {
+	content := "''"
+	if withContent {
+		content = "content"
+	}
+
+	query := `
+		SELECT request_id, document_type_id, name, ` + content + `, updated_at
+		FROM draft_documents
+		WHERE request_id = ANY($1)
+		ORDER BY request_id, document_type_id`
+
+	rows, err := r.repository.Pool().Query(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("error querying draft documents: %w", err)
+	}
+	defer rows.Close()
+
+	var documents []transport.DraftDocumentDataModel
+	for rows.Next() {
+		var model transport.DraftDocumentDataModel
+		if err := rows.Scan(&model.RequestID, &model.Type, &model.Name, &model.Content, &model.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning draft document: %w", err)
+		}
+		documents = append(documents, model)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return documents, nil
+}
+
+// upsertDraftDocuments guarda los archivos del borrador, reemplazando el archivo anterior del mismo tipo
+func upsertDraftDocuments(ctx context.Context, tx pgx.Tx, draftID int64, documents []domain.DraftDocument) error {
+	const query = `
+		INSERT INTO draft_documents (request_id, document_type_id, name, content, updated_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		ON CONFLICT (request_id, document_type_id) DO UPDATE
+		SET name = EXCLUDED.name, content = EXCLUDED.content, updated_at = EXCLUDED.updated_at`
+
+	for _, doc := range documents {
+		if _, err := tx.Exec(ctx, query, draftID, int(doc.Type), doc.Name, doc.Content); err != nil {
+			return fmt.Errorf("%w: %v", transport.ErrCreateDocument, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// requestEndDate es el fin de la ventana de tiempo de una solicitud: la fecha de creación más el tiempo
+// estimado en días, de al menos un día
+const requestEndDate = `%[1]s.created_at + make_interval(days => GREATEST(COALESCE(%[1]s.estimated_time, 0), 1)::int)`
+
+// relatedColumns columnas de las solicitudes superpuestas, en el orden de relatedRequests. Las actividades
+// son las compartidas con la nueva solicitud
+func relatedColumns(activities string) string {
+	return fmt.Sprintf(`
+			r.id,
+			COALESCE(r.file_number, '#' || r.id),
+			COALESCE(rs.name, ''),
+			r.created_at,
+			COALESCE(r.estimated_time, 0),
+			%s,
+			ARRAY(SELECT a.name FROM activities a WHERE a.id = ANY(r.selected_activities) AND a.id = ANY(%s) ORDER BY a.id)`,
+		fmt.Sprintf(requestEndDate, "r"), activities)
+}
+
+// relatedConditions misma propiedad, estado no terminal, alguna actividad en común y ventanas de tiempo superpuestas
+func relatedConditions(property, activities, start, end, statuses string) string {
+	return fmt.Sprintf(`
+			r.property_id = %s
+			AND r.status_id <> ALL(%s)
+			AND r.selected_activities && %s
+			AND r.created_at < %s
+			AND %s > %s`,
+		property, statuses, activities, end, fmt.Sprintf(requestEndDate, "r"), start)
+}
+
+// FindOverlappingRequests busca las solicitudes activas de la propiedad que se superponen con una nueva
+func (r *PostgreSQL) FindOverlappingRequests(ctx context.Context, q domain.DuplicateQuery) ([]domain.RelatedRequest, error) {
+	if q.PropertyID == 0 || len(q.Activities) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT` + relatedColumns("$2::int[]") + `,
+			COALESCE(per.cuil = $7, false)
+		FROM requests r
+		LEFT JOIN request_status rs ON rs.id = r.status_id
+		LEFT JOIN users u ON u.id = r.user_id
+		LEFT JOIN persons per ON per.id = u.person_id
+		WHERE` + relatedConditions("$1", "$2::int[]", "$3", "$4", "$5::int[]") + `
+			AND r.id <> $6
+		ORDER BY r.created_at`
+
+	return r.relatedRequests(ctx, query,
+		q.PropertyID, pq.Array(q.Activities), q.Start, q.End, terminalStatuses(), q.ExcludeRequestID, q.Cuil)
+}
+
+// GetRelatedRequests busca las solicitudes activas de la misma propiedad que se superponen con la
+// solicitud del expediente, para mostrarlas en la verificación
+func (r *PostgreSQL) GetRelatedRequests(ctx context.Context, fileNumber string) ([]domain.RelatedRequest, error) {
+	query := `
+		WITH t AS (
+			SELECT
+				requests.id, requests.user_id, requests.property_id, requests.selected_activities, requests.created_at,
+				` + fmt.Sprintf(requestEndDate, "requests") + ` AS end_date
+			FROM requests
+			WHERE requests.file_number = $1
+		)
+		SELECT` + relatedColumns("t.selected_activities") + `,
+			COALESCE(r.user_id = t.user_id, false)
+		FROM t
+		INNER JOIN requests r ON r.id <> t.id
+		LEFT JOIN request_status rs ON rs.id = r.status_id
+		WHERE` + relatedConditions("t.property_id", "t.selected_activities", "t.created_at", "t.end_date", "$2::int[]") + `
+		ORDER BY r.created_at`
+
+	return r.relatedRequests(ctx, query, fileNumber, terminalStatuses())
+}
+
+func (r *PostgreSQL) relatedRequests(ctx context.Context, query string, args ...interface{}) ([]domain.RelatedRequest, error) {
+	rows, err := r.repository.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying related requests: %w", err)
+	}
+	defer rows.Close()
+
+	related := []domain.RelatedRequest{}
+	for rows.Next() {
+		var req domain.RelatedRequest
+		if err := rows.Scan(
+			&req.ID,
+			&req.FileNumber,
+			&req.Status,
+			&req.CreatedAt,
+			&req.EstimatedTime,
+			&req.EndDate,
+			&req.Activities,
+			&req.SameApplicant,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning related request: %w", err)
+		}
+		related = append(related, req)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return related, nil
+}
+
+func terminalStatuses() interface{} {
+	statuses := make([]int, len(domain.TerminalStatuses))
+	for i, status := range domain.TerminalStatuses {
+		statuses[i] = int(status)
+	}
+	return pq.Array(statuses)
+}
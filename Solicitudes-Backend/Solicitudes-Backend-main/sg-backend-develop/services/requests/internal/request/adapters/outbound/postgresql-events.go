@@ -0,0 +1,76 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// ListenRequestEvents escucha los cambios de estado notificados en el canal request_events y los entrega
+// a fn, hasta que se cancele el contexto o se pierda la conexión. Usa una conexión dedicada del pool
+func (r *PostgreSQL) ListenRequestEvents(ctx context.Context, fn func(domain.RequestEvent)) error {
+	conn, err := r.repository.Pool().Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("error acquiring connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{domain.RequestEventsChannel}.Sanitize()); err != nil {
+		return fmt.Errorf("error listening request events: %w", err)
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("error waiting request events: %w", err)
+		}
+
+		var payload transport.RequestEventPayload
+		if err := json.Unmarshal([]byte(notification.Payload), &payload); err != nil {
+			log.Printf("request events: invalid payload: %v", err)
+			continue
+		}
+
+		fn(transport.ToRequestEvent(&payload))
+	}
+}
+
+// notifyRequestEvent publica el evento en el canal request_events. La notificación se entrega recién
+// cuando se confirma la transacción, y no se entrega si se revierte
+func notifyRequestEvent(ctx context.Context, tx pgx.Tx, requestID int64, eventType domain.TimelineEventType, change domain.StatusChange) error {
+	const query = `
+		SELECT pg_notify($1, json_build_object(
+			'request_id', id,
+			'type', $7::text,
+			'owner_id', COALESCE(user_id, 0),
+			'file_number', COALESCE(file_number, ''),
+			'track', $3::text,
+			'previous_status_id', $4::int,
+			'new_status_id', $5::int,
+			'has_observations', $6::boolean,
+			'change_date', CURRENT_TIMESTAMP
+		)::text)
+		FROM requests
+		WHERE id = $2`
+
+	_, err := tx.Exec(ctx, query,
+		domain.RequestEventsChannel,
+		requestID,
+		string(change.Track),
+		int(change.From),
+		int(change.To),
+		change.Observations != "",
+		string(eventType),
+	)
+	if err != nil {
+		return fmt.Errorf("error notifying request event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// GetStreetAbbreviations obtiene las abreviaturas de los nombres de calles, normalizadas igual que properties.street_search
+func (r *PostgreSQL) GetStreetAbbreviations(ctx context.Context) ([]domain.StreetAbbreviation, error) {
+	const query = `
+		SELECT search_normalize(abbreviation), search_normalize(expansion)
+		FROM street_abbreviations`
+
+	rows, err := r.repository.Pool().Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying street abbreviations: %w", err)
+	}
+	defer rows.Close()
+
+	var abbreviations []domain.StreetAbbreviation
+	for rows.Next() {
+		var abbr domain.StreetAbbreviation
+		if err := rows.Scan(&abbr.Abbreviation, &abbr.Expansion); err != nil {
+			return nil, fmt.Errorf("error scanning street abbreviation: %w", err)
+		}
+		abbreviations = append(abbreviations, abbr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return abbreviations, nil
+}
+
+// GetStreetAliases obtiene los alias y nombres históricos de las calles, normalizados
+func (r *PostgreSQL) GetStreetAliases(ctx context.Context) ([]domain.StreetAlias, error) {
+	const query = `
+		SELECT search_normalize(canonical_name), search_normalize(alias), kind
+		FROM street_aliases
+		ORDER BY id`
+
+	rows, err := r.repository.Pool().Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying street aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []domain.StreetAlias
+	for rows.Next() {
+		var alias domain.StreetAlias
+		var kind string
+		if err := rows.Scan(&alias.CanonicalName, &alias.Alias, &kind); err != nil {
+			return nil, fmt.Errorf("error scanning street alias: %w", err)
+		}
+		alias.Kind = domain.StreetAliasKind(kind)
+		aliases = append(aliases, alias)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return aliases, nil
+}
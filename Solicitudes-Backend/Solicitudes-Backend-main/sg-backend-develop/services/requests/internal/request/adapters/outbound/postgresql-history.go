@@ -0,0 +1,147 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v4"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// GetRequestHistory arma la línea de tiempo de la solicitud a partir de workflow_history y de la
+// fecha de carga de sus documentos, ordenada cronológicamente
+func (r *PostgreSQL) GetRequestHistory(ctx context.Context, fileNumber string) (*domain.RequestHistory, error) {
+	const requestQuery = `
+		SELECT id, user_id
+		FROM requests
+		WHERE file_number = $1`
+
+	history := &domain.RequestHistory{FileNumber: fileNumber}
+	err := r.repository.Pool().QueryRow(ctx, requestQuery, fileNumber).Scan(&history.RequestID, &history.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrRequestNotFound, fileNumber)
+		}
+		return nil, fmt.Errorf("error getting request: %w", err)
+	}
+
+	const historyQuery = `
+		SELECT
+			wh.change_date,
+			wh.track,
+			wh.previous_status_id,
+			ps.name as previous_status_name,
+			wh.new_status_id,
+			ns.name as new_status_name,
+			wh.user_id,
+			NULLIF(TRIM(CONCAT(per.first_name, ' ', per.last_name)), '') as actor_name,
+			wh.observations
+		FROM workflow_history wh
+		LEFT JOIN request_status ps ON ps.id = wh.previous_status_id
+		LEFT JOIN request_status ns ON ns.id = wh.new_status_id
+		LEFT JOIN users u ON u.id = wh.user_id
+		LEFT JOIN persons per ON per.id = u.person_id
+		WHERE wh.request_id = $1
+		ORDER BY wh.change_date, wh.id`
+
+	rows, err := r.repository.Pool().Query(ctx, historyQuery, history.RequestID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying workflow history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var model transport.WorkflowHistoryDataModel
+		if err := rows.Scan(
+			&model.ChangeDate,
+			&model.Track,
+			&model.PreviousStatusID,
+			&model.PreviousStatusLabel,
+			&model.NewStatusID,
+			&model.NewStatusLabel,
+			&model.UserID,
+			&model.ActorName,
+			&model.Observations,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning workflow history: %w", err)
+		}
+		history.Events = append(history.Events, transport.ToWorkflowTimelineEvent(&model))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	const documentQuery = `
+		SELECT
+			documents.created_at,
+			documents.document_type_id,
+			document_types.description,
+			COALESCE(NULLIF(documents.name, ''), documents.filename) as name
+		FROM documents
+		LEFT JOIN document_types ON documents.document_type_id = document_types.id
+		WHERE code = $1
+		ORDER BY documents.created_at, documents.id`
+
+	docRows, err := r.repository.Pool().Query(ctx, documentQuery, fileNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error querying documents: %w", err)
+	}
+	defer docRows.Close()
+
+	for docRows.Next() {
+		var model transport.DocumentHistoryDataModel
+		if err := docRows.Scan(
+			&model.CreatedAt,
+			&model.Type,
+			&model.Description,
+			&model.Name,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning document: %w", err)
+		}
+		history.Events = append(history.Events, transport.ToDocumentTimelineEvent(&model))
+	}
+
+	if err = docRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	sort.SliceStable(history.Events, func(i, j int) bool {
+		return history.Events[i].Date.Before(history.Events[j].Date)
+	})
+
+	return history, nil
+}
+
+// GetUserAccessByCuil obtiene el usuario asociado al CUIL junto con sus roles y los permisos que estos otorgan
+func (r *PostgreSQL) GetUserAccessByCuil(ctx context.Context, cuil string) (*domain.UserAccess, error) {
+	const query = `
+		SELECT
+			u.id,
+			COALESCE(ARRAY_AGG(DISTINCT ro.name) FILTER (WHERE ro.name IS NOT NULL), ARRAY[]::varchar[]) as roles,
+			COALESCE(ARRAY_AGG(DISTINCT pe.name) FILTER (WHERE pe.name IS NOT NULL), ARRAY[]::varchar[]) as permissions
+		FROM users u
+		JOIN persons p ON u.person_id = p.id
+		LEFT JOIN user_roles ur ON ur.user_id = u.id
+		LEFT JOIN roles ro ON ro.id = ur.role_id
+		LEFT JOIN role_permissions rp ON rp.role_id = ro.id
+		LEFT JOIN permissions pe ON pe.id = rp.permission_id
+		WHERE p.cuil = $1
+		AND u.deleted_at IS NULL
+		GROUP BY u.id`
+
+	var access domain.UserAccess
+	err := r.repository.Pool().QueryRow(ctx, query, cuil).Scan(&access.UserID, &access.Roles, &access.Permissions)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: cuil %s", transport.ErrUserNotFound, cuil)
+		}
+		return nil, fmt.Errorf("error finding user by cuil: %v", err)
+	}
+
+	return &access, nil
+}
@@ -0,0 +1,109 @@
+package outbound
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// BeginIdempotentRequest reserva la clave de idempotencia para procesar la solicitud. Si la clave ya existe
+// y no venció, ni quedó abandonada mientras se procesaba, devuelve el registro existente sin reservarla
+func (r *PostgreSQL) BeginIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord, ttl time.Duration) (*domain.IdempotencyRecord, error) {
+	const reserve = `
+		INSERT INTO idempotency_keys (scope, key, fingerprint, expires_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4))
+		ON CONFLICT (scope, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+			status_code = NULL,
+			content_type = NULL,
+			body = NULL,
+			created_at = CURRENT_TIMESTAMP,
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+			OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at <= CURRENT_TIMESTAMP - make_interval(secs => $5))
+		RETURNING id`
+
+	var id int64
+	err := r.repository.Pool().QueryRow(ctx, reserve,
+		record.Scope, record.Key, record.Fingerprint, ttl.Seconds(), domain.IdempotencyLockTimeout.Seconds(),
+	).Scan(&id)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("error reserving idempotency key: %w", err)
+	}
+
+	const query = `
+		SELECT fingerprint, status_code, COALESCE(content_type, ''), body, expires_at
+		FROM idempotency_keys
+		WHERE scope = $1 AND key = $2`
+
+	existing := domain.IdempotencyRecord{Scope: record.Scope, Key: record.Key}
+	var statusCode sql.NullInt64
+	err = r.repository.Pool().QueryRow(ctx, query, record.Scope, record.Key).Scan(
+		&existing.Fingerprint,
+		&statusCode,
+		&existing.ContentType,
+		&existing.Body,
+		&existing.ExpiresAt,
+	)
+	if err != nil {
+		// La clave se liberó entre ambas consultas: la solicitud original falló y se puede reintentar
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrIdempotencyInProgress
+		}
+		return nil, fmt.Errorf("error getting idempotency key: %w", err)
+	}
+
+	existing.Completed = statusCode.Valid
+	existing.StatusCode = int(statusCode.Int64)
+
+	return &existing, nil
+}
+
+// CompleteIdempotentRequest guarda la respuesta de la solicitud para devolverla en los reintentos
+func (r *PostgreSQL) CompleteIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord) error {
+	const query = `
+		UPDATE idempotency_keys
+		SET status_code = $3, content_type = $4, body = $5, completed_at = CURRENT_TIMESTAMP
+		WHERE scope = $1 AND key = $2 AND fingerprint = $6`
+
+	_, err := r.repository.Pool().Exec(ctx, query,
+		record.Scope, record.Key, record.StatusCode, record.ContentType, record.Body, record.Fingerprint)
+	if err != nil {
+		return fmt.Errorf("error saving idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseIdempotentRequest libera la clave de una solicitud que falló, para que se pueda reintentar
+func (r *PostgreSQL) ReleaseIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord) error {
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE scope = $1 AND key = $2 AND fingerprint = $3 AND status_code IS NULL`
+
+	if _, err := r.repository.Pool().Exec(ctx, query, record.Scope, record.Key, record.Fingerprint); err != nil {
+		return fmt.Errorf("error releasing idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys elimina las claves vencidas y devuelve cuántas se eliminaron
+func (r *PostgreSQL) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	tag, err := r.repository.Pool().Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,261 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// GetRequestOwner obtiene el id de la solicitud y el usuario que la presentó
+func (r *PostgreSQL) GetRequestOwner(ctx context.Context, fileNumber string) (int64, int64, error) {
+	const query = `
+		SELECT id, user_id
+		FROM requests
+		WHERE file_number = $1`
+
+	var requestID, userID int64
+	err := r.repository.Pool().QueryRow(ctx, query, fileNumber).Scan(&requestID, &userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, fmt.Errorf("request not found with expedition code (file_number): %s", fileNumber)
+		}
+		return 0, 0, fmt.Errorf("error getting request: %w", err)
+	}
+
+	return requestID, userID, nil
+}
+
+// CreateMessage guarda el mensaje y sus adjuntos junto con las notificaciones a enviar
+func (r *PostgreSQL) CreateMessage(ctx context.Context, fileNumber string, msg *domain.Message, msgs ...domain.OutboxMessage) error {
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertMessage(ctx, tx, fileNumber, msg); err != nil {
+		return err
+	}
+
+	if err := insertOutboxMessages(ctx, tx, msg.RequestID, msgs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// GetMessageThread obtiene los mensajes de la solicitud en orden cronológico, indicando cuáles
+// leyó el usuario userID. Los mensajes propios se consideran leídos
+func (r *PostgreSQL) GetMessageThread(ctx context.Context, fileNumber string, userID int64) (*domain.MessageThread, error) {
+	requestID, ownerID, err := r.GetRequestOwner(ctx, fileNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &domain.MessageThread{
+		RequestID:  requestID,
+		UserID:     ownerID,
+		FileNumber: fileNumber,
+		Messages:   []domain.Message{},
+	}
+
+	const messageQuery = `
+		SELECT
+			m.id,
+			m.request_id,
+			m.user_id,
+			NULLIF(TRIM(CONCAT(per.first_name, ' ', per.last_name)), '') as author_name,
+			m.content,
+			(m.user_id = $2 OR EXISTS (
+				SELECT 1 FROM message_reads mr WHERE mr.message_id = m.id AND mr.user_id = $2
+			)) as read,
+			m.created_at
+		FROM messages m
+		LEFT JOIN users u ON u.id = m.user_id
+		LEFT JOIN persons per ON per.id = u.person_id
+		WHERE m.request_id = $1
+		ORDER BY m.created_at, m.id`
+
+	rows, err := r.repository.Pool().Query(ctx, messageQuery, requestID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying messages: %w", err)
+	}
+	defer rows.Close()
+
+	positions := make(map[int64]int)
+	for rows.Next() {
+		var model transport.MessageDataModel
+		if err := rows.Scan(
+			&model.ID,
+			&model.RequestID,
+			&model.UserID,
+			&model.AuthorName,
+			&model.Content,
+			&model.Read,
+			&model.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning message: %w", err)
+		}
+		positions[model.ID] = len(thread.Messages)
+		thread.Messages = append(thread.Messages, transport.ToMessageDomain(&model))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(thread.Messages) == 0 {
+		return thread, nil
+	}
+
+	const attachmentQuery = `
+		SELECT
+			ma.message_id,
+			d.id,
+			COALESCE(NULLIF(d.name, ''), d.filename) as name,
+			d.content
+		FROM message_attachments ma
+		JOIN messages m ON m.id = ma.message_id
+		JOIN documents d ON d.id = ma.document_id
+		WHERE m.request_id = $1
+		ORDER BY d.id`
+
+	attRows, err := r.repository.Pool().Query(ctx, attachmentQuery, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying message attachments: %w", err)
+	}
+	defer attRows.Close()
+
+	for attRows.Next() {
+		var model transport.MessageAttachmentDataModel
+		if err := attRows.Scan(
+			&model.MessageID,
+			&model.DocumentID,
+			&model.Name,
+			&model.Content,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning message attachment: %w", err)
+		}
+
+		if i, ok := positions[model.MessageID]; ok {
+			thread.Messages[i].Attachments = append(thread.Messages[i].Attachments, transport.ToMessageAttachmentDomain(&model))
+		}
+	}
+
+	if err = attRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return thread, nil
+}
+
+// MarkMessagesRead marca como leídos por el usuario todos los mensajes de la solicitud escritos por otros usuarios
+func (r *PostgreSQL) MarkMessagesRead(ctx context.Context, requestID, userID int64) error {
+	const query = `
+		INSERT INTO message_reads (message_id, user_id, read_at)
+		SELECT id, $2, CURRENT_TIMESTAMP
+		FROM messages
+		WHERE request_id = $1
+		AND user_id IS DISTINCT FROM $2
+		ON CONFLICT (message_id, user_id) DO NOTHING`
+
+	if _, err := r.repository.Pool().Exec(ctx, query, requestID, userID); err != nil {
+		return fmt.Errorf("error marking messages as read: %w", err)
+	}
+
+	return nil
+}
+
+// GetMessageRecipients obtiene los emails de quienes participan de la solicitud (el ciudadano, el personal que
+// la verificó y quienes escribieron en el hilo), excluyendo al autor del mensaje
+func (r *PostgreSQL) GetMessageRecipients(ctx context.Context, requestID, authorID int64) ([]string, error) {
+	const query = `
+		SELECT DISTINCT p.email
+		FROM users u
+		JOIN persons p ON p.id = u.person_id
+		WHERE u.id IN (
+			SELECT user_id FROM requests WHERE id = $1
+			UNION SELECT verified_by FROM requests WHERE id = $1
+			UNION SELECT verified_by_tasks FROM requests WHERE id = $1
+			UNION SELECT user_id FROM messages WHERE request_id = $1
+		)
+		AND u.id <> $2
+		AND u.deleted_at IS NULL
+		AND p.email IS NOT NULL AND p.email <> ''`
+
+	rows, err := r.repository.Pool().Query(ctx, query, requestID, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying message recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("error scanning message recipient: %w", err)
+		}
+		emails = append(emails, email)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return emails, nil
+}
+
+// helpers
+
+// insertMessage guarda el mensaje y sus adjuntos como documentos de la solicitud
+func insertMessage(ctx context.Context, tx pgx.Tx, fileNumber string, msg *domain.Message) error {
+	const messageQuery = `
+		INSERT INTO messages (request_id, user_id, content, sent_date, created_at)
+		VALUES ($1, NULLIF($2, 0), $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING id, created_at`
+
+	err := tx.QueryRow(ctx, messageQuery, msg.RequestID, msg.UserID, msg.Content).Scan(&msg.ID, &msg.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error inserting message: %w", err)
+	}
+
+	const documentQuery = `
+		INSERT INTO documents (code, request_id, document_type_id, file_id, content, original_content, filename, name)
+		VALUES ($1, $2, $3, '', $4, $4, $5, $5)
+		RETURNING id`
+
+	const attachmentQuery = `
+		INSERT INTO message_attachments (message_id, document_id)
+		VALUES ($1, $2)`
+
+	for i, attachment := range msg.Attachments {
+		var documentID int64
+		err := tx.QueryRow(ctx, documentQuery,
+			fileNumber,
+			msg.RequestID,
+			int(domain.DocumentTypeMessageAttachment),
+			attachment.Content,
+			attachment.Name,
+		).Scan(&documentID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", transport.ErrCreateDocument, err)
+		}
+
+		if _, err := tx.Exec(ctx, attachmentQuery, msg.ID, documentID); err != nil {
+			return fmt.Errorf("error inserting message attachment: %w", err)
+		}
+
+		msg.Attachments[i].DocumentID = documentID
+	}
+
+	return nil
+}
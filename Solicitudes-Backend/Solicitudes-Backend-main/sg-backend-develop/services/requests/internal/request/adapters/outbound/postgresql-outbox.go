@@ -0,0 +1,137 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// ClaimOutboxMessages toma un lote de mensajes pendientes (o con el lease vencido) y los marca como
+// "processing" hasta que venza el lease, de modo que varias réplicas no entreguen el mismo mensaje
+func (r *PostgreSQL) ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxMessage, error) {
+	const query = `
+		UPDATE outbox
+		SET
+			status = 'processing',
+			attempts = attempts + 1,
+			locked_until = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id
+			FROM outbox
+			WHERE (status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP)
+			OR (status = 'processing' AND locked_until < CURRENT_TIMESTAMP)
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, request_id, action, payload, status, attempts, last_error, next_attempt_at, created_at`
+
+	rows, err := r.repository.Pool().Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("error claiming outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []domain.OutboxMessage
+	for rows.Next() {
+		var model transport.OutboxDataModel
+		if err := rows.Scan(
+			&model.ID,
+			&model.RequestID,
+			&model.Action,
+			&model.Payload,
+			&model.Status,
+			&model.Attempts,
+			&model.LastError,
+			&model.NextAttemptAt,
+			&model.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning outbox message: %w", err)
+		}
+		msgs = append(msgs, transport.ToOutboxMessageDomain(&model))
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return msgs, nil
+}
+
+// CompleteOutboxMessage marca el mensaje como entregado y encola, en la misma transacción,
+// las acciones que dependen de él
+func (r *PostgreSQL) CompleteOutboxMessage(ctx context.Context, id int64, followUps ...domain.OutboxMessage) error {
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		UPDATE outbox
+		SET status = 'done', locked_until = NULL, last_error = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING request_id`
+
+	var requestID int64
+	if err := tx.QueryRow(ctx, query, id).Scan(&requestID); err != nil {
+		return fmt.Errorf("error completing outbox message %d: %w", id, err)
+	}
+
+	if err := insertOutboxMessages(ctx, tx, requestID, followUps); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// FailOutboxMessage registra el error de entrega y reprograma el mensaje, o lo deja en "dead"
+// cuando se agotaron los reintentos
+func (r *PostgreSQL) FailOutboxMessage(ctx context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error {
+	status := domain.OutboxStatusPending
+	if dead {
+		status = domain.OutboxStatusDead
+	}
+
+	const query = `
+		UPDATE outbox
+		SET
+			status = $1,
+			last_error = $2,
+			next_attempt_at = CURRENT_TIMESTAMP + $3 * INTERVAL '1 second',
+			locked_until = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4`
+
+	_, err := r.repository.Pool().Exec(ctx, query, string(status), lastError, retryIn.Seconds(), id)
+	if err != nil {
+		return fmt.Errorf("error updating outbox message %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// helpers
+func insertOutboxMessages(ctx context.Context, tx pgx.Tx, requestID int64, msgs []domain.OutboxMessage) error {
+	const query = `
+		INSERT INTO outbox (request_id, action, payload)
+		VALUES ($1, $2, $3)`
+
+	for _, msg := range msgs {
+		if _, err := tx.Exec(ctx, query, requestID, string(msg.Action), msg.Payload); err != nil {
+			return fmt.Errorf("error inserting outbox message %s: %w", msg.Action, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// ownersQuery devuelve los titulares principal y secundario de cada registro de owners, uno por fila
+const ownersQuery = `
+		SELECT t.name, COALESCE(t.cuit, ''), t.role
+		FROM owners o
+		CROSS JOIN LATERAL (VALUES
+			(o.primary_owner, o.primary_owner_cuit, 'primary'),
+			(o.secondary_owner, o.secondary_owner_cuit, 'secondary')
+		) AS t(name, cuit, role)
+		WHERE NULLIF(TRIM(t.name), '') IS NOT NULL
+		AND o.abl_id IN (%s)
+		ORDER BY o.owner_id, t.role`
+
+// GetAblOwners obtiene los titulares registrados para el número de ABL
+func (r *PostgreSQL) GetAblOwners(ctx context.Context, ablNumber int64) ([]domain.PropertyOwner, error) {
+	query := fmt.Sprintf(ownersQuery, `SELECT abl_id FROM abl WHERE abl_number = $1`)
+	return r.propertyOwners(ctx, query, ablNumber)
+}
+
+// GetRequestOwners obtiene los titulares registrados para la propiedad de la solicitud
+func (r *PostgreSQL) GetRequestOwners(ctx context.Context, fileNumber string) ([]domain.PropertyOwner, error) {
+	query := fmt.Sprintf(ownersQuery, `
+			SELECT p.abl_id
+			FROM requests
+			INNER JOIN properties p ON p.property_id = requests.property_id
+			WHERE requests.file_number = $1`)
+	return r.propertyOwners(ctx, query, fileNumber)
+}
+
+// GetOwnershipClaimant obtiene el nombre de la persona con el CUIL. Devuelve nil si no existe
+func (r *PostgreSQL) GetOwnershipClaimant(ctx context.Context, cuil string) (*domain.OwnershipClaimant, error) {
+	const query = `SELECT COALESCE(first_name, ''), COALESCE(last_name, ''), cuil FROM persons WHERE cuil = $1`
+
+	var claimant domain.OwnershipClaimant
+	err := r.repository.Pool().QueryRow(ctx, query, cuil).Scan(&claimant.FirstName, &claimant.LastName, &claimant.CUIL)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting person: %w", err)
+	}
+
+	return &claimant, nil
+}
+
+func (r *PostgreSQL) propertyOwners(ctx context.Context, query string, args ...interface{}) ([]domain.PropertyOwner, error) {
+	rows, err := r.repository.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying owners: %w", err)
+	}
+	defer rows.Close()
+
+	var owners []domain.PropertyOwner
+	for rows.Next() {
+		var owner domain.PropertyOwner
+		if err := rows.Scan(&owner.Name, &owner.CUIT, &owner.Role); err != nil {
+			return nil, fmt.Errorf("error scanning owner: %w", err)
+		}
+		owners = append(owners, owner)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return owners, nil
+}
@@ -0,0 +1,192 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// verificationQueueFrom es el FROM de la bandeja de verificación, con su condición base
+const verificationQueueFrom = `
+		FROM requests
+		INNER JOIN users u ON requests.user_id = u.id
+		INNER JOIN persons per ON u.person_id = per.id
+		LEFT JOIN request_types rt ON rt.id = requests.request_type_id
+		INNER JOIN request_status rs ON rs.id = requests.status_id
+		LEFT JOIN request_status st ON st.id = requests.status_id_tasks
+		LEFT JOIN request_status sp ON sp.id = requests.status_id_property
+		LEFT JOIN properties p ON p.property_id = requests.property_id WHERE requests.status_id NOT IN (8, 10, 11)`
+
+// validationQueueFrom es el FROM de la bandeja de validación: solicitudes con ambos circuitos aprobados
+// y los dos documentos de verificación generados
+const validationQueueFrom = `
+		FROM requests
+		INNER JOIN users u ON requests.user_id = u.id
+		INNER JOIN persons per ON u.person_id = per.id
+		LEFT JOIN request_types rt ON rt.id = requests.request_type_id
+		INNER JOIN request_status rs ON rs.id = requests.status_id
+		LEFT JOIN request_status st ON st.id = requests.status_id_tasks
+		LEFT JOIN request_status sp ON sp.id = requests.status_id_property
+		LEFT JOIN properties p ON p.property_id = requests.property_id 
+		WHERE requests.status_id_property = 3 AND requests.status_id_tasks = 3 AND requests.status_id = 1 
+		AND requests.file_number IN (
+			SELECT code 
+			FROM documents 
+			WHERE document_type_id IN (16, 17)
+			GROUP BY code
+			HAVING COUNT(DISTINCT document_type_id) = 2
+		)`
+
+// queueSortColumns traduce las claves de ordenamiento a columnas, evitando interpolar valores del usuario
+var queueSortColumns = map[domain.QueueSortField][]string{
+	domain.QueueSortCreatedAt:  {"requests.created_at"},
+	domain.QueueSortFileNumber: {"requests.file_number"},
+	domain.QueueSortStatus:     {"rs.name"},
+	domain.QueueSortStreet:     {"p.street", "p.number"},
+	domain.QueueSortRequester:  {"per.last_name", "per.first_name"},
+}
+
+// queueFilters arma las condiciones del WHERE de las bandejas a partir de la consulta.
+// Las condiciones se agregan a continuación de la condición base de cada bandeja
+func queueFilters(q domain.QueueQuery) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if q.Status != domain.RequestStatusNone {
+		add("requests.status_id = $%d", int(q.Status))
+	}
+	if q.StatusTasks != domain.RequestStatusNone {
+		add("COALESCE(requests.status_id_tasks, 1) = $%d", int(q.StatusTasks))
+	}
+	if q.StatusProperty != domain.RequestStatusNone {
+		add("COALESCE(requests.status_id_property, 1) = $%d", int(q.StatusProperty))
+	}
+	if !q.CreatedFrom.IsZero() {
+		add("requests.created_at >= $%d", q.CreatedFrom)
+	}
+	if !q.CreatedTo.IsZero() {
+		add("requests.created_at < $%d", q.CreatedTo)
+	}
+	if q.Street != "" {
+		add("unaccent(lower(p.street)) LIKE '%%' || unaccent(lower($%d)) || '%%'", q.Street)
+	}
+	if q.Cuil != "" {
+		add("REGEXP_REPLACE(per.cuil, '[^0-9]', '', 'g') LIKE REGEXP_REPLACE($%d, '[^0-9]', '', 'g') || '%%'", q.Cuil)
+	}
+	if q.FileNumber != "" {
+		add("requests.file_number ILIKE '%%' || $%d || '%%'", q.FileNumber)
+	}
+	if q.AssignedTo != 0 {
+		add("EXISTS (SELECT 1 FROM request_assignments ra WHERE ra.request_id = requests.id AND ra.user_id = $%d AND ra.expires_at > CURRENT_TIMESTAMP)", q.AssignedTo)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " AND " + strings.Join(conditions, " AND "), args
+}
+
+// queueOrderAndPage arma el ORDER BY, LIMIT y OFFSET. argCount es la cantidad de parámetros ya utilizados
+func queueOrderAndPage(q domain.QueueQuery, argCount int) (string, []interface{}) {
+	clause := queueOrder(q) + fmt.Sprintf(" LIMIT $%d OFFSET $%d", argCount+1, argCount+2)
+
+	return clause, []interface{}{q.PageSize, q.Offset()}
+}
+
+// queueOrder arma el ORDER BY de las bandejas
+func queueOrder(q domain.QueueQuery) string {
+	direction := "ASC"
+	if q.SortDesc {
+		direction = "DESC"
+	}
+
+	columns := queueSortColumns[q.SortBy]
+	order := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		order = append(order, column+" "+direction+" NULLS LAST")
+	}
+	order = append(order, "requests.id "+direction)
+
+	return " ORDER BY " + strings.Join(order, ", ")
+}
+
+// ExportRequestsVerifications recorre la bandeja de verificación completa, con los mismos filtros y orden,
+// entregando las solicitudes de a una para no retenerlas en memoria
+func (r *PostgreSQL) ExportRequestsVerifications(ctx context.Context, q domain.QueueQuery, fn func(domain.Verification) error) error {
+	return r.exportQueue(ctx, verificationQueueFrom, q, fn)
+}
+
+// ExportRequestsValidations recorre la bandeja de validación completa, con los mismos filtros y orden
+func (r *PostgreSQL) ExportRequestsValidations(ctx context.Context, q domain.QueueQuery, fn func(domain.Verification) error) error {
+	return r.exportQueue(ctx, validationQueueFrom, q, fn)
+}
+
+func (r *PostgreSQL) exportQueue(ctx context.Context, from string, q domain.QueueQuery, fn func(domain.Verification) error) error {
+	filters, args := queueFilters(q)
+	query := `
+		SELECT
+			requests.id,
+			requests.file_number,
+			COALESCE(rt.description, 'Aviso de obra') as request_type,
+			requests.created_at as deliveryDate,
+			COALESCE(rs.name, 'Pending') as status,
+			COALESCE(st.name, 'Pending') as status_tasks,
+			COALESCE(sp.name, 'Pending') as status_property,
+			per.first_name,
+			per.last_name,
+			per.cuil,
+			p.street, p.number, p.locality,
+			ARRAY(
+				SELECT a.name
+				FROM activities a
+				WHERE a.id = ANY(requests.selected_activities)
+				ORDER BY a.name
+			) as activities` + from + filters + queueOrder(q)
+
+	rows, err := r.repository.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error querying requests: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var req transport.VerificactionDataModel
+		if err := rows.Scan(
+			&req.ID,
+			&req.FileNumber,
+			&req.RequestType,
+			&req.DeliveryDate,
+			&req.Status,
+			&req.StatusTask,
+			&req.StatusProperty,
+			&req.FirstName,
+			&req.LastName,
+			&req.CUIL,
+			&req.AddrStreet,
+			&req.AddrNumber,
+			&req.Locality,
+			&req.Activities,
+		); err != nil {
+			return fmt.Errorf("error scanning request: %w", err)
+		}
+
+		if err := fn(transport.ToVerificationListDomain([]transport.VerificactionDataModel{req})[0]); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return nil
+}
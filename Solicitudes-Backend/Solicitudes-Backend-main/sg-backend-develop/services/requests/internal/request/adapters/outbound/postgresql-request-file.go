@@ -0,0 +1,71 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// GetRequestFiles devuelve los archivos presentados o reenviados con la solicitud que todavía no se
+// enviaron al file-manager, en el orden en que se presentaron
+func (r *PostgreSQL) GetRequestFiles(ctx context.Context, requestID int64) ([]domain.DocumentRequest, error) {
+	const query = `
+		SELECT document_type_id, name, content
+		FROM request_files
+		WHERE request_id = $1
+		ORDER BY id`
+
+	rows, err := r.repository.Pool().Query(ctx, query, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying request files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []domain.DocumentRequest
+	for rows.Next() {
+		var docType int
+		var file domain.DocumentRequest
+		if err := rows.Scan(&docType, &file.Name, &file.Content); err != nil {
+			return nil, fmt.Errorf("error scanning request file: %w", err)
+		}
+		file.Type = domain.DocumentTypeID(docType)
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating request files: %w", err)
+	}
+
+	return files, nil
+}
+
+// helpers
+
+// insertRequestFiles guarda los archivos de la solicitud hasta que se envían al expediente
+func insertRequestFiles(ctx context.Context, tx pgx.Tx, requestID int64, documents []domain.DocumentRequest) error {
+	const query = `
+		INSERT INTO request_files (request_id, document_type_id, name, content)
+		VALUES ($1, $2, $3, $4)`
+
+	for _, doc := range documents {
+		if doc.Content == "" {
+			continue
+		}
+		if _, err := tx.Exec(ctx, query, requestID, int(doc.Type), doc.Name, doc.Content); err != nil {
+			return fmt.Errorf("error inserting request file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteRequestFiles descarta los archivos una vez que el file-manager los incorporó al expediente
+func deleteRequestFiles(ctx context.Context, tx pgx.Tx, requestID int64) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM request_files WHERE request_id = $1`, requestID); err != nil {
+		return fmt.Errorf("error deleting request files: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// GetRequestTypes obtiene los tipos de trámite configurados. Con activeOnly solo devuelve los habilitados
+func (r *PostgreSQL) GetRequestTypes(ctx context.Context, activeOnly bool) ([]domain.RequestType, error) {
+	const query = `
+		SELECT id, name, description, COALESCE(is_active, false), definition
+		FROM request_types
+		WHERE (NOT $1 OR is_active)
+		ORDER BY id`
+
+	rows, err := r.repository.Pool().Query(ctx, query, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("error querying request types: %w", err)
+	}
+	defer rows.Close()
+
+	var requestTypes []domain.RequestType
+	for rows.Next() {
+		var model transport.RequestTypeDataModel
+		if err := rows.Scan(&model.ID, &model.Name, &model.Description, &model.Active, &model.Definition); err != nil {
+			return nil, fmt.Errorf("error scanning request type: %w", err)
+		}
+
+		requestType, err := transport.ToRequestTypeDomain(&model)
+		if err != nil {
+			return nil, err
+		}
+		requestTypes = append(requestTypes, *requestType)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return requestTypes, nil
+}
+
+func (r *PostgreSQL) GetRequestType(ctx context.Context, id int) (*domain.RequestType, error) {
+	const query = `
+		SELECT id, name, description, COALESCE(is_active, false), definition
+		FROM request_types
+		WHERE id = $1`
+
+	var model transport.RequestTypeDataModel
+	err := r.repository.Pool().QueryRow(ctx, query, id).Scan(&model.ID, &model.Name, &model.Description, &model.Active, &model.Definition)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", domain.ErrRequestTypeNotFound, id)
+		}
+		return nil, fmt.Errorf("error getting request type: %w", err)
+	}
+
+	return transport.ToRequestTypeDomain(&model)
+}
+
+// GetRequestTypeByFileNumber obtiene el tipo de trámite de la solicitud
+func (r *PostgreSQL) GetRequestTypeByFileNumber(ctx context.Context, fileNumber string) (*domain.RequestType, error) {
+	const query = `
+		SELECT COALESCE(request_type_id, $2)
+		FROM requests
+		WHERE file_number = $1`
+
+	var requestTypeID int
+	err := r.repository.Pool().QueryRow(ctx, query, fileNumber, domain.DefaultRequestTypeID).Scan(&requestTypeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("request not found with expedition code (file_number): %s", fileNumber)
+		}
+		return nil, fmt.Errorf("error getting request: %w", err)
+	}
+
+	return r.GetRequestType(ctx, requestTypeID)
+}
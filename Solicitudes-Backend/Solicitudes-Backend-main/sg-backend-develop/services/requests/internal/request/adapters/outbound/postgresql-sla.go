@@ -0,0 +1,145 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// slaColumns son las columnas necesarias para calcular el vencimiento de la etapa de cada solicitud,
+// en el orden de transport.SLADataModel. Las fechas de inicio de cada etapa salen de workflow_history:
+// la verificación empieza al generarse el expediente o al reenviar el ciudadano la solicitud, la validación
+// con el último cambio de los circuitos y la respuesta del ciudadano al pedirle cambios
+const slaColumns = `,
+			COALESCE(requests.request_type_id, 0),
+			COALESCE(requests.status_id, 0),
+			COALESCE(requests.status_id_tasks, 1),
+			COALESCE(requests.status_id_property, 1),
+			(SELECT MAX(wh.change_date) FROM workflow_history wh
+				WHERE wh.request_id = requests.id AND wh.track = 'global' AND wh.new_status_id = 1 AND wh.previous_status_id IN (7, 8)),
+			(SELECT MAX(wh.change_date) FROM workflow_history wh
+				WHERE wh.request_id = requests.id AND wh.track IN ('tasks', 'property')),
+			(SELECT MAX(wh.change_date) FROM workflow_history wh
+				WHERE wh.request_id = requests.id AND wh.track = 'global' AND wh.new_status_id = 7)`
+
+// GetSLAPolicies obtiene los plazos configurados para cada tipo de trámite y etapa
+func (r *PostgreSQL) GetSLAPolicies(ctx context.Context) (domain.SLAPolicies, error) {
+	const query = `
+		SELECT COALESCE(request_type_id, 0), stage, business_days, at_risk_days
+		FROM sla_policies`
+
+	rows, err := r.repository.Pool().Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying sla policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies domain.SLAPolicies
+	for rows.Next() {
+		var policy domain.SLAPolicy
+		var stage string
+		if err := rows.Scan(&policy.RequestTypeID, &stage, &policy.BusinessDays, &policy.AtRiskDays); err != nil {
+			return nil, fmt.Errorf("error scanning sla policy: %w", err)
+		}
+		policy.Stage = domain.SLAStage(stage)
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return policies, nil
+}
+
+// GetHolidays obtiene los feriados que no se cuentan como días hábiles
+func (r *PostgreSQL) GetHolidays(ctx context.Context) ([]time.Time, error) {
+	rows, err := r.repository.Pool().Query(ctx, `SELECT date FROM holidays ORDER BY date`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying holidays: %w", err)
+	}
+	defer rows.Close()
+
+	var holidays []time.Time
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, fmt.Errorf("error scanning holiday: %w", err)
+		}
+		holidays = append(holidays, date)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return holidays, nil
+}
+
+// GetOpenRequests obtiene las solicitudes que esperan una verificación, una validación o
+// una respuesta del ciudadano, que son las alcanzadas por los plazos
+func (r *PostgreSQL) GetOpenRequests(ctx context.Context) ([]domain.Verification, error) {
+	query := `
+		SELECT
+			requests.id,
+			requests.file_number,
+			COALESCE(rt.description, 'Aviso de obra') as request_type,
+			requests.created_at as deliveryDate,
+			COALESCE(rs.name, 'Pending') as status,
+			COALESCE(st.name, 'Pending') as status_tasks,
+			COALESCE(sp.name, 'Pending') as status_property,
+			per.first_name,
+			per.last_name,
+			per.cuil,
+			p.street, p.number, p.locality` + slaColumns + `
+		FROM requests
+		INNER JOIN users u ON requests.user_id = u.id
+		INNER JOIN persons per ON u.person_id = per.id
+		LEFT JOIN request_types rt ON rt.id = requests.request_type_id
+		INNER JOIN request_status rs ON rs.id = requests.status_id
+		LEFT JOIN request_status st ON st.id = requests.status_id_tasks
+		LEFT JOIN request_status sp ON sp.id = requests.status_id_property
+		LEFT JOIN properties p ON p.property_id = requests.property_id
+		WHERE requests.status_id IN (1, 7, 9)
+		AND requests.file_number IS NOT NULL
+		ORDER BY requests.created_at`
+
+	rows, err := r.repository.Pool().Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []transport.VerificactionDataModel
+	for rows.Next() {
+		var req transport.VerificactionDataModel
+		dest := append([]interface{}{
+			&req.ID,
+			&req.FileNumber,
+			&req.RequestType,
+			&req.DeliveryDate,
+			&req.Status,
+			&req.StatusTask,
+			&req.StatusProperty,
+			&req.FirstName,
+			&req.LastName,
+			&req.CUIL,
+			&req.AddrStreet,
+			&req.AddrNumber,
+			&req.Locality,
+		}, req.SLA.Fields()...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("error scanning request: %w", err)
+		}
+		requests = append(requests, req)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return transport.ToVerificationListDomain(requests), nil
+}
@@ -0,0 +1,219 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// GetStats calcula los indicadores del backoffice sobre las solicitudes creadas en el rango de fechas.
+// Los borradores no se consideran
+func (r *PostgreSQL) GetStats(ctx context.Context, q domain.StatsQuery) (*domain.Stats, error) {
+	args := statsArgs(q)
+	stats := &domain.Stats{}
+
+	var err error
+	stats.ByStatus, err = r.statCounts(ctx, `
+		SELECT rs.id, rs.name, COUNT(*)
+		FROM requests
+		INNER JOIN request_status rs ON rs.id = requests.status_id
+		WHERE requests.status_id IS DISTINCT FROM $3`+statsRange("requests.created_at")+`
+		GROUP BY rs.id, rs.name
+		ORDER BY rs.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, count := range stats.ByStatus {
+		stats.Total += count.Count
+	}
+
+	stats.ByRequestType, err = r.statCounts(ctx, `
+		SELECT COALESCE(rt.id, 0), COALESCE(rt.name, 'Sin tipo'), COUNT(*)
+		FROM requests
+		LEFT JOIN request_types rt ON rt.id = requests.request_type_id
+		WHERE requests.status_id IS DISTINCT FROM $3`+statsRange("requests.created_at")+`
+		GROUP BY rt.id, rt.name
+		ORDER BY COUNT(*) DESC, rt.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TopActivities, err = r.statCounts(ctx, `
+		SELECT a.id, a.name, COUNT(*)
+		FROM requests
+		CROSS JOIN LATERAL UNNEST(requests.selected_activities) AS activity_id
+		INNER JOIN activities a ON a.id = activity_id
+		WHERE requests.status_id IS DISTINCT FROM $3`+statsRange("requests.created_at")+`
+		GROUP BY a.id, a.name
+		ORDER BY COUNT(*) DESC, a.id
+		LIMIT $4`, append(args, domain.TopActivitiesLimit)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.Weekly, err = r.weeklyThroughput(ctx, args); err != nil {
+		return nil, err
+	}
+
+	if stats.ValidationTime, err = r.validationTime(ctx, args); err != nil {
+		return nil, err
+	}
+
+	if stats.ObservationRates, err = r.observationRates(ctx, args); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// weeklyThroughput cuenta las solicitudes presentadas y validadas en cada semana del rango.
+// La fecha de validación sale de workflow_history
+func (r *PostgreSQL) weeklyThroughput(ctx context.Context, args []interface{}) ([]domain.WeeklyThroughput, error) {
+	query := `
+		SELECT week, SUM(filed)::bigint, SUM(validated)::bigint
+		FROM (
+			SELECT date_trunc('week', requests.created_at) AS week, 1 AS filed, 0 AS validated
+			FROM requests
+			WHERE requests.status_id IS DISTINCT FROM $3` + statsRange("requests.created_at") + `
+			UNION ALL
+			SELECT date_trunc('week', wh.change_date), 0, 1
+			FROM workflow_history wh
+			WHERE wh.track = 'global' AND wh.new_status_id = 4` + statsRange("wh.change_date") + `
+		) t
+		GROUP BY week
+		ORDER BY week`
+
+	rows, err := r.repository.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying weekly throughput: %w", err)
+	}
+	defer rows.Close()
+
+	weeks := []domain.WeeklyThroughput{}
+	for rows.Next() {
+		var week domain.WeeklyThroughput
+		if err := rows.Scan(&week.WeekStart, &week.Filed, &week.Validated); err != nil {
+			return nil, fmt.Errorf("error scanning weekly throughput: %w", err)
+		}
+		weeks = append(weeks, week)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return weeks, nil
+}
+
+// validationTime calcula la mediana y el percentil 90 del tiempo entre la creación y la validación
+func (r *PostgreSQL) validationTime(ctx context.Context, args []interface{}) (domain.DurationStats, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds), 0),
+			COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY seconds), 0)
+		FROM (
+			SELECT EXTRACT(EPOCH FROM MIN(wh.change_date) - requests.created_at)::float8 AS seconds
+			FROM requests
+			INNER JOIN workflow_history wh ON wh.request_id = requests.id AND wh.track = 'global' AND wh.new_status_id = 4
+			WHERE requests.status_id IS DISTINCT FROM $3` + statsRange("requests.created_at") + `
+			GROUP BY requests.id, requests.created_at
+		) t`
+
+	var count int64
+	var median, p90 float64
+	if err := r.repository.Pool().QueryRow(ctx, query, args...).Scan(&count, &median, &p90); err != nil {
+		return domain.DurationStats{}, fmt.Errorf("error querying validation time: %w", err)
+	}
+
+	return domain.DurationStats{
+		Count:  count,
+		Median: time.Duration(median * float64(time.Second)),
+		P90:    time.Duration(p90 * float64(time.Second)),
+	}, nil
+}
+
+// observationRates cuenta, por circuito, las verificaciones y las observaciones registradas en workflow_history
+func (r *PostgreSQL) observationRates(ctx context.Context, args []interface{}) ([]domain.ObservationRate, error) {
+	query := `
+		SELECT
+			wh.track,
+			COUNT(*) FILTER (WHERE wh.new_status_id IN (3, 5)),
+			COUNT(*) FILTER (WHERE wh.new_status_id = 5),
+			COUNT(DISTINCT wh.request_id) FILTER (WHERE wh.new_status_id IN (3, 5)),
+			COUNT(DISTINCT wh.request_id) FILTER (WHERE wh.new_status_id = 5)
+		FROM workflow_history wh
+		INNER JOIN requests ON requests.id = wh.request_id
+		WHERE wh.track IN ('tasks', 'property')
+			AND requests.status_id IS DISTINCT FROM $3` + statsRange("requests.created_at") + `
+		GROUP BY wh.track
+		ORDER BY wh.track`
+
+	rows, err := r.repository.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying observation rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []domain.ObservationRate{}
+	for rows.Next() {
+		var rate domain.ObservationRate
+		var track string
+		if err := rows.Scan(&track, &rate.Verifications, &rate.Observations, &rate.RequestsVerified, &rate.RequestsObserved); err != nil {
+			return nil, fmt.Errorf("error scanning observation rate: %w", err)
+		}
+		rate.Track = domain.StatusTrack(track)
+		rates = append(rates, rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (r *PostgreSQL) statCounts(ctx context.Context, query string, args ...interface{}) ([]domain.StatCount, error) {
+	rows, err := r.repository.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying stats: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []domain.StatCount{}
+	for rows.Next() {
+		var count domain.StatCount
+		if err := rows.Scan(&count.ID, &count.Name, &count.Count); err != nil {
+			return nil, fmt.Errorf("error scanning stats: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+// statsRange filtra la columna por el rango de fechas. Los límites son siempre $1 y $2 y se ignoran si son NULL
+func statsRange(column string) string {
+	return fmt.Sprintf(`
+			AND ($1::timestamp IS NULL OR %[1]s >= $1)
+			AND ($2::timestamp IS NULL OR %[1]s < $2)`, column)
+}
+
+// statsArgs devuelve los límites del rango de fechas y, en $3, el estado de los borradores, que no se consideran
+func statsArgs(q domain.StatsQuery) []interface{} {
+	var from, to *time.Time
+	if !q.From.IsZero() {
+		from = &q.From
+	}
+	if !q.To.IsZero() {
+		to = &q.To
+	}
+	return []interface{}{from, to, int(domain.RequestStatusDraft)}
+}
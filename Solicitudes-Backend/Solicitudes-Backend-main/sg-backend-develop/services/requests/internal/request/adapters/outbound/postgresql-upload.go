@@ -0,0 +1,152 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/lib/pq"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// CreateUploadSession registra la subida antes de recibir el primer fragmento
+func (r *PostgreSQL) CreateUploadSession(ctx context.Context, session *domain.UploadSession) error {
+	const query = `
+		INSERT INTO uploads (id, user_id, document_type_id, filename, upload_length, upload_offset, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at`
+
+	err := r.repository.Pool().QueryRow(ctx, query,
+		session.ID,
+		session.UserID,
+		int(session.Type),
+		session.Filename,
+		session.Length,
+		session.Offset,
+		session.ExpiresAt,
+	).Scan(&session.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating upload: %w", err)
+	}
+
+	return nil
+}
+
+// GetUploadSession obtiene la subida del usuario
+func (r *PostgreSQL) GetUploadSession(ctx context.Context, id string, userID int64) (*domain.UploadSession, error) {
+	const query = `
+		SELECT id, user_id, document_type_id, filename, upload_length, upload_offset, expires_at, created_at
+		FROM uploads
+		WHERE id = $1 AND user_id = $2`
+
+	var session domain.UploadSession
+	var docType int
+	err := r.repository.Pool().QueryRow(ctx, query, id, userID).Scan(
+		&session.ID,
+		&session.UserID,
+		&docType,
+		&session.Filename,
+		&session.Length,
+		&session.Offset,
+		&session.ExpiresAt,
+		&session.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUploadNotFound, id)
+		}
+		return nil, fmt.Errorf("error getting upload: %w", err)
+	}
+	session.Type = domain.DocumentTypeID(docType)
+
+	return &session, nil
+}
+
+// UpdateUploadOffset avanza el offset de la subida solo si sigue en from y guarda el fragmento con write.
+// La subida queda bloqueada hasta terminar, así dos pedidos concurrentes no escriben el mismo fragmento, y
+// si write falla el offset no cambia
+func (r *PostgreSQL) UpdateUploadOffset(ctx context.Context, id string, from, to int64, write func() error) error {
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	const lockQuery = `
+		SELECT upload_offset
+		FROM uploads
+		WHERE id = $1 AND expires_at > CURRENT_TIMESTAMP
+		FOR UPDATE`
+
+	var offset int64
+	if err := tx.QueryRow(ctx, lockQuery, id).Scan(&offset); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %s", domain.ErrUploadNotFound, id)
+		}
+		return fmt.Errorf("error getting upload: %w", err)
+	}
+	if offset != from {
+		return fmt.Errorf("%w: %s", domain.ErrUploadOffsetMismatch, id)
+	}
+
+	const updateQuery = `
+		UPDATE uploads
+		SET upload_offset = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	if _, err := tx.Exec(ctx, updateQuery, id, to); err != nil {
+		return fmt.Errorf("error updating upload offset: %w", err)
+	}
+
+	if err := write(); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteUploadSessions elimina las subidas indicadas
+func (r *PostgreSQL) DeleteUploadSessions(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := r.repository.Pool().Exec(ctx, `DELETE FROM uploads WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return fmt.Errorf("error deleting uploads: %w", err)
+	}
+
+	return nil
+}
+
+// GetExpiredUploadSessions devuelve las subidas vencidas, de la más antigua a la más reciente
+func (r *PostgreSQL) GetExpiredUploadSessions(ctx context.Context, limit int) ([]string, error) {
+	const query = `
+		SELECT id
+		FROM uploads
+		WHERE expires_at <= CURRENT_TIMESTAMP
+		ORDER BY expires_at
+		LIMIT $1`
+
+	rows, err := r.repository.Pool().Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying expired uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning upload: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -0,0 +1,360 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/lib/pq"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+const webhookSubscriptionColumns = `id, name, url, secret, event_types, active, created_by, created_at, updated_at`
+
+const webhookDeliveryColumns = `
+	id, subscription_id, request_id, event_type, payload, status, attempts, last_status_code,
+	last_error, next_attempt_at, delivered_at, redelivery_of, created_at`
+
+// CreateWebhookSubscription guarda la suscripción y completa su id y fechas
+func (r *PostgreSQL) CreateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	const query = `
+		INSERT INTO webhook_subscriptions (name, url, secret, event_types, active, created_by)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, 0))
+		RETURNING id, created_at, updated_at`
+
+	err := r.repository.Pool().QueryRow(ctx, query,
+		sub.Name, sub.URL, sub.Secret, pq.Array(transport.ToWebhookEventTypes(sub.EventTypes)), sub.Active, sub.CreatedBy,
+	).Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("error creating webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQL) GetWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY id`
+
+	rows, err := r.repository.Pool().Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := []domain.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return subs, nil
+}
+
+func (r *PostgreSQL) GetWebhookSubscription(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	sub, err := scanWebhookSubscription(r.repository.Pool().QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", domain.ErrWebhookNotFound, id)
+		}
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (r *PostgreSQL) UpdateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	const query = `
+		UPDATE webhook_subscriptions
+		SET name = $2, url = $3, secret = $4, event_types = $5, active = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.repository.Pool().QueryRow(ctx, query,
+		sub.ID, sub.Name, sub.URL, sub.Secret, pq.Array(transport.ToWebhookEventTypes(sub.EventTypes)), sub.Active,
+	).Scan(&sub.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %d", domain.ErrWebhookNotFound, sub.ID)
+		}
+		return fmt.Errorf("error updating webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhookSubscription elimina la suscripción junto con su registro de envíos
+func (r *PostgreSQL) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	tag, err := r.repository.Pool().Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting webhook subscription: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrWebhookNotFound, id)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries devuelve los últimos envíos de la suscripción, del más reciente al más antiguo
+func (r *PostgreSQL) GetWebhookDeliveries(ctx context.Context, q domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2::text = '' OR status = $2::text)
+		ORDER BY id DESC
+		LIMIT $3`
+
+	rows, err := r.repository.Pool().Query(ctx, query, q.SubscriptionID, string(q.Status), q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RedeliverWebhook encola un nuevo envío con el contenido de un envío anterior de la suscripción. El envío
+// original se conserva en el registro
+func (r *PostgreSQL) RedeliverWebhook(ctx context.Context, subscriptionID, deliveryID int64) (*domain.WebhookDelivery, error) {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, request_id, event_type, payload, redelivery_of)
+		SELECT subscription_id, request_id, event_type, payload, id
+		FROM webhook_deliveries
+		WHERE id = $2 AND subscription_id = $1
+		RETURNING ` + webhookDeliveryColumns
+
+	delivery, err := scanWebhookDelivery(r.repository.Pool().QueryRow(ctx, query, subscriptionID, deliveryID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", domain.ErrWebhookDeliveryNotFound, deliveryID)
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// ClaimWebhookDeliveries toma un lote de envíos pendientes (o con el lease vencido) de las suscripciones
+// activas y los marca como "processing" hasta que venza el lease, igual que el outbox
+func (r *PostgreSQL) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	const query = `
+		UPDATE webhook_deliveries d
+		SET
+			status = 'processing',
+			attempts = d.attempts + 1,
+			locked_until = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second',
+			updated_at = CURRENT_TIMESTAMP
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id
+		AND d.id IN (
+			SELECT pending.id
+			FROM webhook_deliveries pending
+			INNER JOIN webhook_subscriptions ps ON ps.id = pending.subscription_id
+			WHERE ps.active
+			AND ((pending.status = 'pending' AND pending.next_attempt_at <= CURRENT_TIMESTAMP)
+			OR (pending.status = 'processing' AND pending.locked_until < CURRENT_TIMESTAMP))
+			ORDER BY pending.id
+			LIMIT $1
+			FOR UPDATE OF pending SKIP LOCKED
+		)
+		RETURNING
+			d.id, d.subscription_id, d.request_id, d.event_type, d.payload, d.status, d.attempts, d.last_status_code,
+			d.last_error, d.next_attempt_at, d.delivered_at, d.redelivery_of, d.created_at, s.url, s.secret`
+
+	rows, err := r.repository.Pool().Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("error claiming webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		var url, secret string
+		delivery, err := scanWebhookDelivery(rows, &url, &secret)
+		if err != nil {
+			return nil, err
+		}
+		delivery.URL = url
+		delivery.Secret = secret
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// CompleteWebhookDelivery marca el envío como entregado
+func (r *PostgreSQL) CompleteWebhookDelivery(ctx context.Context, id int64, statusCode int) error {
+	const query = `
+		UPDATE webhook_deliveries
+		SET
+			status = 'done',
+			last_status_code = $2,
+			last_error = NULL,
+			delivered_at = CURRENT_TIMESTAMP,
+			locked_until = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	if _, err := r.repository.Pool().Exec(ctx, query, id, statusCode); err != nil {
+		return fmt.Errorf("error completing webhook delivery %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// FailWebhookDelivery registra el error del envío y lo reprograma, o lo deja en "dead" cuando se agotaron
+// los reintentos. El código de estado es 0 si el suscriptor no respondió
+func (r *PostgreSQL) FailWebhookDelivery(ctx context.Context, id int64, statusCode int, lastError string, retryIn time.Duration, dead bool) error {
+	status := domain.WebhookDeliveryPending
+	if dead {
+		status = domain.WebhookDeliveryDead
+	}
+
+	const query = `
+		UPDATE webhook_deliveries
+		SET
+			status = $2,
+			last_status_code = NULLIF($3, 0),
+			last_error = $4,
+			next_attempt_at = CURRENT_TIMESTAMP + $5 * INTERVAL '1 second',
+			locked_until = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	_, err := r.repository.Pool().Exec(ctx, query, id, string(status), statusCode, lastError, retryIn.Seconds())
+	if err != nil {
+		return fmt.Errorf("error updating webhook delivery %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// helpers
+
+// insertWebhookDeliveries encola el evento para las suscripciones activas que lo reciben, en la misma
+// transacción que el cambio de estado
+func insertWebhookDeliveries(ctx context.Context, tx pgx.Tx, requestID int64, eventType domain.TimelineEventType, change domain.StatusChange) error {
+	const query = `
+		INSERT INTO webhook_deliveries (subscription_id, request_id, event_type, payload)
+		SELECT s.id, r.id, $2::text, json_build_object(
+			'event', $2::text,
+			'occurred_at', CURRENT_TIMESTAMP,
+			'request', json_build_object(
+				'id', r.id,
+				'file_number', r.file_number,
+				'request_type', rt.name,
+				'previous_status_id', NULLIF($3::int, 0),
+				'status_id', $4::int,
+				'has_observations', $5::boolean,
+				'property', json_build_object(
+					'id', p.property_id,
+					'street', TRIM(p.street),
+					'number', p.number,
+					'abl_number', a.abl_number
+				)
+			)
+		)
+		FROM webhook_subscriptions s
+		CROSS JOIN requests r
+		LEFT JOIN request_types rt ON rt.id = r.request_type_id
+		LEFT JOIN properties p ON p.property_id = r.property_id
+		LEFT JOIN abl a ON a.abl_id = p.abl_id
+		WHERE r.id = $1
+		AND s.active
+		AND (cardinality(s.event_types) = 0 OR $2::text = ANY(s.event_types))`
+
+	_, err := tx.Exec(ctx, query,
+		requestID,
+		string(eventType),
+		int(change.From),
+		int(change.To),
+		change.Observations != "",
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func scanWebhookSubscription(row pgx.Row) (domain.WebhookSubscription, error) {
+	var model transport.WebhookSubscriptionDataModel
+	if err := row.Scan(
+		&model.ID,
+		&model.Name,
+		&model.URL,
+		&model.Secret,
+		&model.EventTypes,
+		&model.Active,
+		&model.CreatedBy,
+		&model.CreatedAt,
+		&model.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.WebhookSubscription{}, err
+		}
+		return domain.WebhookSubscription{}, fmt.Errorf("error scanning webhook subscription: %w", err)
+	}
+
+	return transport.ToWebhookSubscriptionDomain(&model), nil
+}
+
+// scanWebhookDelivery lee las columnas de webhookDeliveryColumns seguidas de las columnas extra
+func scanWebhookDelivery(row pgx.Row, extra ...any) (domain.WebhookDelivery, error) {
+	var model transport.WebhookDeliveryDataModel
+	dest := append([]any{
+		&model.ID,
+		&model.SubscriptionID,
+		&model.RequestID,
+		&model.EventType,
+		&model.Payload,
+		&model.Status,
+		&model.Attempts,
+		&model.LastStatusCode,
+		&model.LastError,
+		&model.NextAttemptAt,
+		&model.DeliveredAt,
+		&model.RedeliveryOf,
+		&model.CreatedAt,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.WebhookDelivery{}, err
+		}
+		return domain.WebhookDelivery{}, fmt.Errorf("error scanning webhook delivery: %w", err)
+	}
+
+	return transport.ToWebhookDeliveryDomain(&model), nil
+}
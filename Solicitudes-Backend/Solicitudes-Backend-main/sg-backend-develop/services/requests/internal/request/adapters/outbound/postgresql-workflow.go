@@ -0,0 +1,192 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+
+	transport "github.com/teamcubation/sg-backend/services/requests/internal/request/adapters/outbound/transport"
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// TransitionRequestStatus cambia el estado global de la solicitud si se encuentra en el estado esperado,
+// registrando el cambio en workflow_history. Lo utilizan los procesos internos, sin usuario asociado
+func (r *PostgreSQL) TransitionRequestStatus(ctx context.Context, id int64, from, to domain.RequestStatus, observations string) error {
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	statuses, err := lockRequestStatusesByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	change, err := statuses.Transition(domain.StatusTrackGlobal, from, to, 0, observations)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		UPDATE requests
+		SET status_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2`
+
+	if _, err := tx.Exec(ctx, query, int(to), id); err != nil {
+		return fmt.Errorf("error updating request status: %w", err)
+	}
+
+	if err := insertWorkflowHistory(ctx, tx, id, change); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// WithdrawRequest da de baja la solicitud a pedido del ciudadano que la presentó. El motivo queda
+// registrado en workflow_history junto con los mensajes de outbox del IF y el email de desistimiento
+func (r *PostgreSQL) WithdrawRequest(ctx context.Context, withdrawal *domain.Withdrawal, msgs ...domain.OutboxMessage) error {
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	statuses, err := lockRequestStatuses(ctx, tx, withdrawal.FileNumber)
+	if err != nil {
+		return err
+	}
+
+	change, err := statuses.Transition(domain.StatusTrackGlobal, statuses.Global, domain.RequestStatusWithdrawn, withdrawal.UserID, withdrawal.Reason)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+		UPDATE requests
+		SET status_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND user_id = $3`
+
+	tag, err := tx.Exec(ctx, query, int(domain.RequestStatusWithdrawn), statuses.RequestID, withdrawal.UserID)
+	if err != nil {
+		return fmt.Errorf("error updating request status: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrRequestAccessDenied, withdrawal.FileNumber)
+	}
+
+	if err := insertWorkflowHistory(ctx, tx, statuses.RequestID, change); err != nil {
+		return err
+	}
+
+	if err := insertOutboxMessages(ctx, tx, statuses.RequestID, msgs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
+	return nil
+}
+
+// helpers
+
+// lockRequestStatuses bloquea la solicitud hasta el fin de la transacción y devuelve el estado de cada circuito.
+// Los circuitos de verificación sin estado se consideran pendientes
+func lockRequestStatuses(ctx context.Context, tx pgx.Tx, fileNumber string) (domain.RequestStatuses, error) {
+	return selectRequestStatusesForUpdate(ctx, tx, "file_number", fileNumber)
+}
+
+// trackStatusColumn devuelve la columna de requests que guarda el estado del circuito
+func trackStatusColumn(track domain.StatusTrack) string {
+	switch track {
+	case domain.StatusTrackTasks:
+		return "status_id_tasks"
+	case domain.StatusTrackProperty:
+		return "status_id_property"
+	default:
+		return "status_id"
+	}
+}
+
+func lockRequestStatusesByID(ctx context.Context, tx pgx.Tx, id int64) (domain.RequestStatuses, error) {
+	return selectRequestStatusesForUpdate(ctx, tx, "id", id)
+}
+
+func selectRequestStatusesForUpdate(ctx context.Context, tx pgx.Tx, column string, value any) (domain.RequestStatuses, error) {
+	query := `
+		SELECT
+			id,
+			COALESCE(status_id, 0),
+			COALESCE(status_id_tasks, 1),
+			COALESCE(status_id_property, 1)
+		FROM requests
+		WHERE ` + column + ` = $1
+		FOR UPDATE`
+
+	var requestID int64
+	var global, tasks, property int
+	err := tx.QueryRow(ctx, query, value).Scan(&requestID, &global, &tasks, &property)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.RequestStatuses{}, fmt.Errorf("%w: request not found", transport.ErrUpdateRequest)
+		}
+		return domain.RequestStatuses{}, fmt.Errorf("error fetching request status: %w", err)
+	}
+
+	return domain.RequestStatuses{
+		RequestID: requestID,
+		Global:    domain.RequestStatus(global),
+		Tasks:     domain.RequestStatus(tasks),
+		Property:  domain.RequestStatus(property),
+	}, nil
+}
+
+// insertWorkflowHistory registra los cambios de estado, los notifica en el canal request_events y encola los
+// webhooks de los eventos del ciclo de vida. El webhook de alta se encola al asignar el número de expediente
+// (ver UpdateRequest). Los cambios que no modifican el estado no se registran, salvo el rechazo de la validación
+func insertWorkflowHistory(ctx context.Context, tx pgx.Tx, requestID int64, changes ...domain.StatusChange) error {
+	const query = `
+		INSERT INTO workflow_history (request_id, track, previous_status_id, new_status_id, user_id, observations, change_date)
+		VALUES ($1, $2, NULLIF($3, 0), $4, NULLIF($5, 0), NULLIF($6, ''), CURRENT_TIMESTAMP)`
+
+	for _, change := range changes {
+		if !change.Recorded() {
+			continue
+		}
+
+		_, err := tx.Exec(ctx, query,
+			requestID,
+			string(change.Track),
+			int(change.From),
+			int(change.To),
+			change.UserID,
+			change.Observations,
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting workflow history: %w", err)
+		}
+
+		eventType := domain.TimelineEventTypeFor(change.Track, change.From, change.To)
+		if err := notifyRequestEvent(ctx, tx, requestID, eventType, change); err != nil {
+			return err
+		}
+
+		if eventType != domain.TimelineEventCreated && domain.IsWebhookEvent(eventType) {
+			if err := insertWebhookDeliveries(ctx, tx, requestID, eventType, change); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
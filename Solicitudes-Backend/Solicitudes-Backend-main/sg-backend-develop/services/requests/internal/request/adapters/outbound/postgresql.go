@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v4"
@@ -67,16 +68,19 @@ func (r *PostgreSQL) GetAllRequestsByUserID(ctx context.Context, userID int64) (
             COALESCE(r.abl_debt, '') as abl_debt,
             COALESCE(r.selected_activities, ARRAY[]::integer[]) as selected_activities,
             COALESCE(r.estimated_time, 0) as estimated_time,
-            COALESCE(r.insurance, FALSE) as insurance
+            COALESCE(r.insurance, FALSE) as insurance,
+            COALESCE(rt.name, '') as request_type_name
         FROM 
             public.requests r
             LEFT JOIN public.request_status rs ON r.status_id = rs.id
+            LEFT JOIN public.request_types rt ON r.request_type_id = rt.id
         WHERE 
             r.user_id = $1
+            AND r.status_id IS DISTINCT FROM $2
         ORDER BY 
             r.created_at DESC`
 
-	rows, err := r.repository.Pool().Query(ctx, query, userID)
+	rows, err := r.repository.Pool().Query(ctx, query, userID, int(domain.RequestStatusDraft))
 	if err != nil {
 		return nil, fmt.Errorf("error querying requests: %w", err)
 	}
@@ -101,6 +105,7 @@ func (r *PostgreSQL) GetAllRequestsByUserID(ctx context.Context, userID int64) (
 			&req.SelectedActivities,
 			&req.EstimatedTime,
 			&req.Insurance,
+			&req.RequestTypeName,
 		); err != nil {
 			return nil, fmt.Errorf("error scanning request: %w", err)
 		}
@@ -115,80 +120,54 @@ func (r *PostgreSQL) GetAllRequestsByUserID(ctx context.Context, userID int64) (
 	return transport.ToRequestDomainList(requests), nil
 }
 
+// GetSuggestions busca las propiedades que coinciden con la calle, la altura y el número de ABL ingresados.
+// La calle se compara contra la columna normalizada street_search (índice de trigramas), tolerando errores de
+// tipeo; la altura y el ABL se buscan por prefijo. Primero se devuelven las coincidencias exactas y por prefijo
+// y luego las más parecidas
 func (r *PostgreSQL) GetSuggestions(ctx context.Context, addrName string, addrNum, ablNum int64) ([]domain.Suggestion, error) {
-	// Inicializar patrones con "%"
-	addrNamePattern := "%"
-	addrNumPattern := "%"
-	ablNumPattern := "%"
-
-	// Construir patrones basados en los parámetros proporcionados
-	if addrName != "" {
-		addrNamePattern += addrName + "%"
-	}
-	if addrNum > 0 {
-		addrNumPattern = fmt.Sprintf("%d%%", addrNum)
-	}
-	if ablNum > 0 {
-		ablNumPattern = fmt.Sprintf("%d%%", ablNum)
+	if addrName == "" && addrNum <= 0 && ablNum <= 0 {
+		return nil, fmt.Errorf("no se proporcionaron parámetros de búsqueda")
 	}
 
-	// Variables para la consulta y los argumentos
 	var (
-		query       string
-		args        []interface{}
-		orderBy     string
-		whereClause string
+		args       []interface{}
+		conditions []string
+		orderBy    []string
 	)
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
 	if addrName != "" {
-		whereClause = `
-			regexp_replace(unaccent(lower(p.street)), '[^a-z]', '', 'g')
-				ILIKE '%' || regexp_replace(unaccent(lower($1)), '[^a-z]', '', 'g') || '%' AND
-			REGEXP_REPLACE(CAST(p.number AS TEXT), '[^0-9]', '', 'g') ILIKE '%' || REGEXP_REPLACE($2, '[^0-9]', '', 'g') || '%' AND
-			REGEXP_REPLACE(CAST(a.abl_number AS TEXT), '[^0-9]', '', 'g') ILIKE '%' || REGEXP_REPLACE($3, '[^0-9]', '', 'g') || '%'
-			`
-		args = append(args, addrNamePattern, addrNumPattern, ablNumPattern)
-		orderBy = `
-			a.abl_number ASC, 
-			p.street, 
-			p.number`
-	} else if ablNum > 0 {
-		// Caso 2: addrName está vacío pero ablNum está presente
-		whereClause = `
-            REGEXP_REPLACE(CAST(a.abl_number AS TEXT), '[^0-9]', '', 'g') ILIKE '%' || REGEXP_REPLACE($1, '[^0-9]', '', 'g') || '%'`
-		args = append(args, ablNumPattern)
-		orderBy = `
-            a.abl_number ASC, 
-            p.street, 
-            p.number`
-	} else if addrNum > 0 {
-		// Caso 3: Tanto addrName como ablNum están vacíos, pero addrNum está presente
-		whereClause = `
-            REGEXP_REPLACE(CAST(p.number AS TEXT), '[^0-9]', '', 'g') ILIKE '%' || REGEXP_REPLACE($1, '[^0-9]', '', 'g') || '%'`
-		args = append(args, addrNumPattern)
-		orderBy = `
-            a.abl_number ASC, 
-            p.number, 
-            p.street`
-	} else {
-		// Caso 4: Ningún parámetro está presente
-		return nil, fmt.Errorf("no se proporcionaron parámetros de búsqueda")
+		contains, prefix, text := arg("%"+addrName+"%"), arg(addrName+"%"), arg(addrName)
+		conditions = append(conditions, fmt.Sprintf("(p.street_search LIKE %s OR %s <%% p.street_search)", contains, text))
+		orderBy = append(orderBy,
+			fmt.Sprintf("p.street_search LIKE %s DESC", prefix),
+			fmt.Sprintf("word_similarity(%[1]s, p.street_search) DESC, similarity(%[1]s, p.street_search) DESC", text))
+	}
+	if addrNum > 0 {
+		conditions = append(conditions, fmt.Sprintf("p.number::text LIKE %s", arg(fmt.Sprintf("%d%%", addrNum))))
+		orderBy = append(orderBy, fmt.Sprintf("p.number = %s DESC", arg(addrNum)))
 	}
+	if ablNum > 0 {
+		conditions = append(conditions, fmt.Sprintf("a.abl_number::text LIKE %s", arg(fmt.Sprintf("%d%%", ablNum))))
+		orderBy = append(orderBy, fmt.Sprintf("a.abl_number = %s DESC", arg(ablNum)))
+	}
+	orderBy = append(orderBy, "p.street_search", "p.number", "a.abl_number")
+
+	query := fmt.Sprintf(`
+		SELECT
+			TRIM(p.street) AS street,
+			p.number AS number,
+			a.abl_number AS abl_number,
+			p.property_id
+		FROM properties p
+		LEFT JOIN abl a ON p.abl_id = a.abl_id
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s`, strings.Join(conditions, " AND "), strings.Join(orderBy, ", "), arg(domain.MaxSuggestions))
 
-	// Construir la consulta SQL completa
-	query = fmt.Sprintf(`
-        SELECT
-            TRIM(p.street) AS street,
-            p.number AS number, 
-            a.abl_number AS abl_number, 
-            p.property_id
-        FROM properties p
-        LEFT JOIN abl a ON p.abl_id = a.abl_id
-        WHERE %s
-        ORDER BY %s
-        LIMIT 100;`, whereClause, orderBy)
-
-	// Ejecutar la consulta
 	var suggestions []transport.SuggestionDataModel
 	if err := r.repository.SelectContext(ctx, &suggestions, query, args...); err != nil {
 		return nil, fmt.Errorf("failed to get suggestions from repository: %w", err)
@@ -197,40 +176,6 @@ func (r *PostgreSQL) GetSuggestions(ctx context.Context, addrName string, addrNu
 	return transport.ToSuggestionDomainList(suggestions), nil
 }
 
-func (r *PostgreSQL) CheckAblOwnership(ctx context.Context, cuil string, ablNumb int) (bool, error) {
-	const query = `
-		WITH person_info AS (
-			SELECT  
-				TRIM(last_name || ' ' || first_name) as full_name,
-				cuil
-			FROM persons 
-			-- WHERE cuil = '20345678901'
-			WHERE cuil = $1
-		), 
-		abl_info AS (
-			SELECT abl_id, abl_number
-			FROM public.abl 
-			WHERE abl_number = $2
-		)
-		SELECT EXISTS (
-			SELECT 1
-			FROM person_info p
-			CROSS JOIN abl_info ai
-			JOIN public.owners o ON o.abl_id = ai.abl_id
-			WHERE 
-				REGEXP_REPLACE(LOWER(UNACCENT(TRIM(p.full_name))), '[^a-z]', '', 'g') = REGEXP_REPLACE(LOWER(UNACCENT(TRIM(o.primary_owner))), '[^a-z]', '', 'g')
-				OR REGEXP_REPLACE(LOWER(UNACCENT(TRIM(p.full_name))), '[^a-z]', '', 'g') = REGEXP_REPLACE(LOWER(UNACCENT(TRIM(o.secondary_owner))), '[^a-z]', '', 'g')
-		);`
-
-	var exists bool
-	err := r.repository.QueryRowContext(ctx, query, cuil, ablNumb).Scan(&exists)
-	if err != nil {
-		return false, fmt.Errorf("checking ABL ownership: %w", err)
-	}
-
-	return exists, nil
-}
-
 // verificacion ciudadano: potestad inmuble propietario
 func (r *PostgreSQL) RequestsVerifications(ctx context.Context, cuil string) (*domain.Verification, error) {
 	const query = `
@@ -246,7 +191,7 @@ func (r *PostgreSQL) RequestsVerifications(ctx context.Context, cuil string) (*d
 			COALESCE(d.document_type_id::text, 'Sin tipo de documento') as document_type_id
 		FROM persons per
 		LEFT JOIN users u ON u.person_id = per.id
-		LEFT JOIN requests r ON r.user_id = u.id
+		LEFT JOIN requests r ON r.user_id = u.id AND r.status_id IS DISTINCT FROM $2
 		LEFT JOIN request_types rt ON rt.id = r.request_type_id
 		LEFT JOIN request_status rs ON rs.id = r.status_id
 		LEFT JOIN properties p ON p.property_id = r.property_id
@@ -257,7 +202,7 @@ func (r *PostgreSQL) RequestsVerifications(ctx context.Context, cuil string) (*d
 		LIMIT 1`
 
 	var dataModel transport.VerificactionDataModel
-	err := r.repository.QueryRowContext(ctx, query, cuil).Scan(
+	err := r.repository.QueryRowContext(ctx, query, cuil, int(domain.RequestStatusDraft)).Scan(
 		&dataModel.PropertyOwner,
 		&dataModel.AddrStreet,
 		&dataModel.AddrNumber,
@@ -343,8 +288,18 @@ func (r *PostgreSQL) GetRequestByCode(ctx context.Context, code string) (*domain
 	}, nil
 }
 
-func (r *PostgreSQL) GetAllRequestsVerifications(ctx context.Context) ([]domain.Verification, error) {
-	const query = `
+func (r *PostgreSQL) GetAllRequestsVerifications(ctx context.Context, q domain.QueueQuery) (*domain.VerificationPage, error) {
+	const from = verificationQueueFrom
+
+	filters, args := queueFilters(q)
+
+	var total int64
+	if err := r.repository.Pool().QueryRow(ctx, "SELECT COUNT(*)"+from+filters, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("error counting requests: %w", err)
+	}
+
+	page, pageArgs := queueOrderAndPage(q, len(args))
+	query := `
 		SELECT  
 			requests.id,
 			requests.file_number,
@@ -356,17 +311,9 @@ func (r *PostgreSQL) GetAllRequestsVerifications(ctx context.Context) ([]domain.
 			per.first_name,
 			per.last_name,
 			per.cuil,
-			p.street, p.number, p.locality
-		FROM requests
-		INNER JOIN users u ON requests.user_id = u.id
-		INNER JOIN persons per ON u.person_id = per.id
-		LEFT JOIN request_types rt ON rt.id = requests.request_type_id
-		INNER JOIN request_status rs ON rs.id = requests.status_id
-		LEFT JOIN request_status st ON st.id = requests.status_id_tasks
-		LEFT JOIN request_status sp ON sp.id = requests.status_id_property
-		LEFT JOIN properties p ON p.property_id = requests.property_id WHERE requests.status_id != 8`
+			p.street, p.number, p.locality` + slaColumns + from + filters + page
 
-	rows, err := r.repository.Pool().Query(ctx, query)
+	rows, err := r.repository.Pool().Query(ctx, query, append(args, pageArgs...)...)
 	if err != nil {
 		fmt.Println(err)
 		return nil, fmt.Errorf("error querying requests: %w", err)
@@ -376,7 +323,7 @@ func (r *PostgreSQL) GetAllRequestsVerifications(ctx context.Context) ([]domain.
 	var requests []transport.VerificactionDataModel
 	for rows.Next() {
 		var req transport.VerificactionDataModel
-		if err := rows.Scan(
+		dest := append([]interface{}{
 			&req.ID,
 			&req.FileNumber,
 			&req.RequestType,
@@ -390,7 +337,8 @@ func (r *PostgreSQL) GetAllRequestsVerifications(ctx context.Context) ([]domain.
 			&req.AddrStreet,
 			&req.AddrNumber,
 			&req.Locality,
-		); err != nil {
+		}, req.SLA.Fields()...)
+		if err := rows.Scan(dest...); err != nil {
 			fmt.Println(err)
 			return nil, fmt.Errorf("error scanning request: %w", err)
 		}
@@ -401,11 +349,26 @@ func (r *PostgreSQL) GetAllRequestsVerifications(ctx context.Context) ([]domain.
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	return transport.ToVerificationListDomain(requests), nil
+	return &domain.VerificationPage{
+		Items:    transport.ToVerificationListDomain(requests),
+		Total:    total,
+		Page:     q.Page,
+		PageSize: q.PageSize,
+	}, nil
 }
 
-func (r *PostgreSQL) GetAllRequestsValidations(ctx context.Context) ([]domain.Verification, error) {
-	const query = `
+func (r *PostgreSQL) GetAllRequestsValidations(ctx context.Context, q domain.QueueQuery) (*domain.VerificationPage, error) {
+	const from = validationQueueFrom
+
+	filters, args := queueFilters(q)
+
+	var total int64
+	if err := r.repository.Pool().QueryRow(ctx, "SELECT COUNT(*)"+from+filters, args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("error counting requests: %w", err)
+	}
+
+	page, pageArgs := queueOrderAndPage(q, len(args))
+	query := `
 		SELECT  
 			requests.id,
 			requests.file_number,
@@ -415,23 +378,9 @@ func (r *PostgreSQL) GetAllRequestsValidations(ctx context.Context) ([]domain.Ve
 			per.first_name,
 			per.last_name,
 			per.cuil,
-			p.street, p.number, p.locality
-		FROM requests
-		INNER JOIN users u ON requests.user_id = u.id
-		INNER JOIN persons per ON u.person_id = per.id
-		LEFT JOIN request_types rt ON rt.id = requests.request_type_id
-		INNER JOIN request_status rs ON rs.id = requests.status_id
-		LEFT JOIN properties p ON p.property_id = requests.property_id 
-		WHERE requests.status_id_property = 3 AND requests.status_id_tasks = 3 AND requests.status_id = 1 
-		AND requests.file_number IN (
-			SELECT code 
-			FROM documents 
-			WHERE document_type_id IN (16, 17)
-			GROUP BY code
-			HAVING COUNT(DISTINCT document_type_id) = 2
-		)`
-
-	rows, err := r.repository.Pool().Query(ctx, query)
+			p.street, p.number, p.locality` + slaColumns + from + filters + page
+
+	rows, err := r.repository.Pool().Query(ctx, query, append(args, pageArgs...)...)
 	if err != nil {
 		fmt.Println(err)
 		return nil, fmt.Errorf("error querying requests: %w", err)
@@ -441,7 +390,7 @@ func (r *PostgreSQL) GetAllRequestsValidations(ctx context.Context) ([]domain.Ve
 	var requests []transport.VerificactionDataModel
 	for rows.Next() {
 		var req transport.VerificactionDataModel
-		if err := rows.Scan(
+		dest := append([]interface{}{
 			&req.ID,
 			&req.FileNumber,
 			&req.RequestType,
@@ -453,7 +402,8 @@ func (r *PostgreSQL) GetAllRequestsValidations(ctx context.Context) ([]domain.Ve
 			&req.AddrStreet,
 			&req.AddrNumber,
 			&req.Locality,
-		); err != nil {
+		}, req.SLA.Fields()...)
+		if err := rows.Scan(dest...); err != nil {
 			fmt.Println(err)
 			return nil, fmt.Errorf("error scanning request: %w", err)
 		}
@@ -464,7 +414,12 @@ func (r *PostgreSQL) GetAllRequestsValidations(ctx context.Context) ([]domain.Ve
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	return transport.ToVerificationListDomain(requests), nil
+	return &domain.VerificationPage{
+		Items:    transport.ToVerificationListDomain(requests),
+		Total:    total,
+		Page:     q.Page,
+		PageSize: q.PageSize,
+	}, nil
 }
 
 func (r *PostgreSQL) GetRequestByFileNumber(ctx context.Context, id string) (*domain.Request, error) {
@@ -569,12 +524,15 @@ func (r *PostgreSQL) GetDocumentsByCode(ctx context.Context, id string) ([]domai
 			documents.id as id,
 			document_type_id,
 			document_types.description,
-			file_id
+			file_id,
+			COALESCE(is_verified, false),
+			documents.observations
 		FROM documents 
 		INNER JOIN document_types ON documents.document_type_id = document_types.id
-		WHERE code = $1 
+		WHERE code = $1 AND NOT superseded
 		AND document_types.description IS NOT NULL AND document_types.description != '' 
-		AND file_id IS NOT NULL AND file_id != ''`
+		AND file_id IS NOT NULL AND file_id != ''
+		ORDER BY documents.id`
 
 	rows, err := r.repository.Pool().Query(ctx, query, id)
 	if err != nil {
@@ -591,6 +549,8 @@ func (r *PostgreSQL) GetDocumentsByCode(ctx context.Context, id string) ([]domai
 			&req.Type,
 			&req.Description,
 			&req.FileID,
+			&req.Verified,
+			&req.Observations,
 		); err != nil {
 			fmt.Println(err)
 			return nil, fmt.Errorf("error scanning request: %w", err)
@@ -614,7 +574,7 @@ func (r *PostgreSQL) GetValidationDocumentsByCode(ctx context.Context, id string
 			file_id
 		FROM documents 
 		INNER JOIN document_types ON documents.document_type_id = document_types.id
-		WHERE code = $1 AND document_type_id IN (1, 2, 3, 17, 13, 15, 16) 
+		WHERE code = $1 AND document_type_id IN (1, 2, 3, 17, 13, 15, 16) AND NOT superseded
 		AND file_id IS NOT NULL AND file_id != '' 
 		ORDER BY 
 			CASE 
@@ -666,7 +626,7 @@ func (r *PostgreSQL) GetReplacementIFDocumentsByCode(ctx context.Context, id str
 			file_id
 		FROM documents 
 		INNER JOIN document_types ON documents.document_type_id = document_types.id
-		WHERE code = $1 AND file_id IS NOT NULL AND file_id != ''`
+		WHERE code = $1 AND NOT superseded AND file_id IS NOT NULL AND file_id != ''`
 
 	rows, err := r.repository.Pool().Query(ctx, query+insuranceDocQuery, id)
 	if err != nil {
@@ -702,7 +662,7 @@ func (r *PostgreSQL) GetInsuranceDocumentByCode(ctx context.Context, id string,
 		SELECT 
 			file_id
 		FROM documents 
-		WHERE code = $1 AND (document_type_id = 13 OR document_type_id = 15) LIMIT 1`
+		WHERE code = $1 AND (document_type_id = 13 OR document_type_id = 15) AND NOT superseded LIMIT 1`
 
 	var fileNumber string
 	err := r.repository.QueryRowContext(ctx, query, id).Scan(&fileNumber)
@@ -770,7 +730,7 @@ func (r *PostgreSQL) GetRequestPersonByCuil(ctx context.Context, cuil string) (*
 	return transport.RequestPersonDataModelToRequestDomain(&model), nil
 }
 
-func (r *PostgreSQL) CreateRequestByCuil(ctx context.Context, req *domain.Request) error {
+func (r *PostgreSQL) CreateRequestByCuil(ctx context.Context, req *domain.Request, msgs ...domain.OutboxMessage) error {
 	if req == nil {
 		return fmt.Errorf("nil request")
 	}
@@ -782,7 +742,7 @@ func (r *PostgreSQL) CreateRequestByCuil(ctx context.Context, req *domain.Reques
 
 	req.UserID = userID
 
-	err = r.CreateRequestByUserID(ctx, req)
+	err = r.CreateRequestByUserID(ctx, req, msgs...)
 	if err != nil {
 		return err
 	}
@@ -790,7 +750,7 @@ func (r *PostgreSQL) CreateRequestByCuil(ctx context.Context, req *domain.Reques
 	return nil
 }
 
-func (r *PostgreSQL) CreateRequestByUserID(ctx context.Context, req *domain.Request) error {
+func (r *PostgreSQL) CreateRequestByUserID(ctx context.Context, req *domain.Request, msgs ...domain.OutboxMessage) error {
 	if req == nil {
 		return fmt.Errorf("nil request")
 	}
@@ -803,8 +763,14 @@ func (r *PostgreSQL) CreateRequestByUserID(ctx context.Context, req *domain.Requ
 
 	reqDataModel := transport.ToCreateRequestDataModel(req)
 
-	reqDataModel.RequestTypeID = 1
-	reqDataModel.StatusID = 8 // Processing
+	requestType, err := r.GetRequestType(ctx, reqDataModel.RequestTypeID)
+	if err != nil {
+		return err
+	}
+
+	// Los circuitos que el tipo de trámite no utiliza quedan aprobados
+	statusTasks, statusProperty := requestType.InitialTrackStatuses()
+	reqDataModel.StatusID = int(domain.RequestStatusProcessing)
 
 	const query = `
         INSERT INTO requests (
@@ -822,12 +788,15 @@ func (r *PostgreSQL) CreateRequestByUserID(ctx context.Context, req *domain.Requ
             abl_debt,
             estimated_time,
             insurance,
-            selected_activities
+            selected_activities,
+            form_data,
+            status_id_tasks,
+            status_id_property
         ) VALUES (
             $1, $2, $3, $4, $5, $6, $7, $8,
             CURRENT_TIMESTAMP,
             CURRENT_TIMESTAMP,
-            $9, $10, $11, $12, $13
+            $9, $10, $11, $12, $13, $14, $15, $16
         ) RETURNING id`
 
 	var requestID int64
@@ -845,12 +814,32 @@ func (r *PostgreSQL) CreateRequestByUserID(ctx context.Context, req *domain.Requ
 		reqDataModel.EstimatedTime,
 		reqDataModel.Insurance,
 		pq.Array(reqDataModel.SelectedActivities),
+		reqDataModel.FormData,
+		int(statusTasks),
+		int(statusProperty),
 	).Scan(&requestID)
 
 	if err != nil {
 		return fmt.Errorf("%w: %v", transport.ErrCreateRequest, err)
 	}
 
+	change, err := domain.NewStatusChange(domain.StatusTrackGlobal, domain.RequestStatusNone, domain.RequestStatusProcessing, reqDataModel.UserID, "")
+	if err != nil {
+		return err
+	}
+
+	if err := insertWorkflowHistory(ctx, tx, requestID, change); err != nil {
+		return err
+	}
+
+	if err := insertRequestFiles(ctx, tx, requestID, req.Documents); err != nil {
+		return err
+	}
+
+	if err := insertOutboxMessages(ctx, tx, requestID, msgs); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return fmt.Errorf("error committing transaction: %v", err)
 	}
@@ -860,56 +849,114 @@ func (r *PostgreSQL) CreateRequestByUserID(ctx context.Context, req *domain.Requ
 	return nil
 }
 
+// UpdateRequest asigna el número de expediente a la solicitud, lo notifica en el canal request_events y
+// encola el webhook de alta, que recién ahora tiene el expediente. Los archivos presentados ya están en el
+// expediente y se descartan. El estado no cambia: el file-manager pasa la solicitud a pendiente cuando
+// termina de guardar los documentos
 func (r *PostgreSQL) UpdateRequest(ctx context.Context, id int64, code string) error {
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE requests 
 		SET file_number = $1 
 		WHERE id = $2
+		RETURNING COALESCE(status_id, 0)
 	`
 
-	_, err := r.repository.Pool().Exec(ctx, query, code, id)
-	if err != nil {
+	var status int
+	if err := tx.QueryRow(ctx, query, code, id).Scan(&status); err != nil {
 		return fmt.Errorf("error updating document: %w", err)
 	}
 
+	change := domain.StatusChange{
+		Track: domain.StatusTrackGlobal,
+		From:  domain.RequestStatus(status),
+		To:    domain.RequestStatus(status),
+	}
+	if err := notifyRequestEvent(ctx, tx, id, domain.TimelineEventRecordAssigned, change); err != nil {
+		return err
+	}
+
+	created := domain.StatusChange{Track: domain.StatusTrackGlobal, To: domain.RequestStatus(status)}
+	if err := insertWebhookDeliveries(ctx, tx, id, domain.TimelineEventCreated, created); err != nil {
+		return err
+	}
+
+	if err := deleteRequestFiles(ctx, tx, id); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+
 	return nil
 }
 
-func (r *PostgreSQL) UpdateRequestWithObservations(ctx context.Context, req *domain.VerifiedRequest) (string, string, string, error) {
+func (r *PostgreSQL) UpdateRequestWithObservations(ctx context.Context, req *domain.VerifiedRequest, msgs ...domain.OutboxMessage) (string, string, string, error) {
 	userID, err := r.findUserIDFromCuil(ctx, req.Cuil)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	// var currentGlobalStatus int
-	// err = r.repository.Pool().QueryRow(ctx, `
-	// 	SELECT status_id
-	// 	FROM requests
-	// 	WHERE file_number = $1`, req.FileNumber).Scan(&currentGlobalStatus)
-	// if err != nil {
-	// 	return "", "", "", fmt.Errorf("error fetching current global status: %w", err)
-	// }
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return "", "", "", fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	statuses, err := lockRequestStatuses(ctx, tx, req.FileNumber)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	track := domain.StatusTrackProperty
+	if req.VerificationType == taskType {
+		track = domain.StatusTrackTasks
+	}
 
-	newStatusID := 9
-	statusID := 3
+	if err := checkAssignment(ctx, tx, statuses.RequestID, track, userID); err != nil {
+		return "", "", "", err
+	}
+
+	trackStatus := domain.RequestStatusApproved
 	if req.Observations != "" {
-		newStatusID = 7
-		statusID = 5
+		trackStatus = domain.RequestStatusObserved
+	}
+
+	trackChange, err := statuses.Transition(track, statuses.Of(track), trackStatus, userID, req.Observations)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if track == domain.StatusTrackTasks {
+		statuses.Tasks = trackStatus
+	} else {
+		statuses.Property = trackStatus
+	}
+
+	globalChange, err := statuses.Transition(domain.StatusTrackGlobal, statuses.Global, statuses.Verified(), userID, req.Observations)
+	if err != nil {
+		return "", "", "", err
 	}
 
 	var set string
-	if req.VerificationType == taskType {
-		set = fmt.Sprintf("SET observations_tasks = $1, verified_by_tasks = $2, verification_date_tasks = $3, status_id_tasks = %d, status_id = %d", statusID, newStatusID)
+	if track == domain.StatusTrackTasks {
+		set = "SET observations_tasks = $1, verified_by_tasks = $2, verification_date_tasks = $3, status_id_tasks = $4, status_id = $5"
 	} else {
-		set = fmt.Sprintf("SET observations = $1, verified_by = $2, verification_date = $3, status_id_property = %d, status_id = %d", statusID, newStatusID)
+		set = "SET observations = $1, verified_by = $2, verification_date = $3, status_id_property = $4, status_id = $5"
 	}
 
-	args := []interface{}{req.Observations, userID, time.Now(), req.FileNumber}
-	where := "WHERE file_number = $4"
+	args := []interface{}{req.Observations, userID, time.Now(), int(trackChange.To), int(globalChange.To), statuses.RequestID}
+	where := "WHERE id = $6"
 	if len(req.SelectedActivities) > 0 {
-		set += ", selected_activities = $4"
-		args = []interface{}{req.Observations, userID, time.Now(), pq.Array(req.SelectedActivities), req.FileNumber}
-		where = "WHERE file_number = $5"
+		set += ", selected_activities = $6"
+		args = []interface{}{req.Observations, userID, time.Now(), int(trackChange.To), int(globalChange.To), pq.Array(req.SelectedActivities), statuses.RequestID}
+		where = "WHERE id = $7"
 	}
 
 	query := `
@@ -920,11 +967,39 @@ func (r *PostgreSQL) UpdateRequestWithObservations(ctx context.Context, req *dom
 	var observationsProperty sql.NullString
 	var observationsTasks sql.NullString
 	var userIDToMail int64
-	err = r.repository.Pool().QueryRow(ctx, query, args...).Scan(&observationsProperty, &observationsTasks, &userIDToMail)
+	err = tx.QueryRow(ctx, query, args...).Scan(&observationsProperty, &observationsTasks, &userIDToMail)
 	if err != nil {
 		return "", "", "", fmt.Errorf("error updating requests: %w", err)
 	}
 
+	if err := updateDocumentDecisions(ctx, tx, req.FileNumber, req.Documents); err != nil {
+		return "", "", "", err
+	}
+
+	if err := insertWorkflowHistory(ctx, tx, statuses.RequestID, trackChange, globalChange); err != nil {
+		return "", "", "", err
+	}
+
+	if err := deleteAssignment(ctx, tx, statuses.RequestID, track); err != nil {
+		return "", "", "", err
+	}
+
+	// Las observaciones quedan además en el hilo de mensajes de la solicitud
+	if req.Observations != "" {
+		msg := &domain.Message{RequestID: statuses.RequestID, UserID: userID, Content: req.Observations}
+		if err := insertMessage(ctx, tx, req.FileNumber, msg); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	if err := insertOutboxMessages(ctx, tx, statuses.RequestID, msgs); err != nil {
+		return "", "", "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", "", "", fmt.Errorf("error committing transaction: %v", err)
+	}
+
 	email, err := r.GetPersonByUserID(ctx, userIDToMail)
 	if err != nil {
 		return "", "", "", fmt.Errorf("error updating requests: %w", err)
@@ -933,64 +1008,85 @@ func (r *PostgreSQL) UpdateRequestWithObservations(ctx context.Context, req *dom
 	return observationsProperty.String, observationsTasks.String, email, nil
 }
 
-func (r *PostgreSQL) ValidateRequest(ctx context.Context, req *domain.ValidateRequest) (int64, error) {
+func (r *PostgreSQL) ValidateRequest(ctx context.Context, req *domain.ValidateRequest, msgs ...domain.OutboxMessage) (int64, error) {
 	userID, err := r.findUserIDFromCuil(ctx, req.Cuil)
 	if err != nil {
 		return userID, err
 	}
 
-	set := "SET validated_by = $1, verification_complete = true, validation_date = $2, status_id = 4"
-	if !req.IsValid {
-		set = "SET validated_by = $1, validation_date = $2, status_id = 1, status_id_tasks = 1, status_id_property = 1"
+	tx, err := r.repository.Pool().Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error starting transaction: %v", err)
 	}
+	defer tx.Rollback(ctx)
 
-	query := `
-		UPDATE requests 
-		` + set + `
-		WHERE file_number = $3 RETURNING user_id`
-
-	// _, err = r.repository.Pool().Exec(ctx, query, userID, time.Now(), req.FileNumber)
-	// if err != nil {
-	// 	return fmt.Errorf("error validating requests: %w", err)
-	// }
-
-	var userIDToMail int64
-	err = r.repository.Pool().QueryRow(ctx, query, userID, time.Now(), req.FileNumber).Scan(&userIDToMail)
+	statuses, err := lockRequestStatuses(ctx, tx, req.FileNumber)
 	if err != nil {
-		return userIDToMail, fmt.Errorf("error updating requests: %w", err)
+		return 0, err
 	}
 
-	return userIDToMail, nil
-}
+	if !statuses.CanBeValidated() {
+		return 0, fmt.Errorf("%w: request %s is not pending validation", domain.ErrInvalidStatusTransition, req.FileNumber)
+	}
+
+	var changes []domain.StatusChange
+	set := "SET validated_by = $1, verification_complete = true, validation_date = $2, status_id = $3"
+	if req.IsValid {
+		change, err := statuses.Transition(domain.StatusTrackGlobal, statuses.Global, domain.RequestStatusValidated, userID, "")
+		if err != nil {
+			return 0, err
+		}
+		changes = append(changes, change)
+	} else {
+		requestType, err := r.GetRequestTypeByFileNumber(ctx, req.FileNumber)
+		if err != nil {
+			return 0, err
+		}
+
+		// Al rechazar la validación los circuitos del tipo de trámite vuelven a quedar pendientes de
+		// verificación; los que no utiliza siguen aprobados. El motivo queda en el cambio global, que es
+		// el que ve el ciudadano
+		change, err := statuses.Transition(domain.StatusTrackGlobal, statuses.Global, domain.RequestStatusPending, userID, req.Observations)
+		if err != nil {
+			return 0, err
+		}
+		changes = append(changes, change)
+
+		set = "SET validated_by = $1, validation_date = $2, status_id = $3"
+		for _, track := range requestType.Tracks {
+			change, err := statuses.Transition(track, statuses.Of(track), domain.RequestStatusPending, userID, "")
+			if err != nil {
+				return 0, err
+			}
+			changes = append(changes, change)
+			set += ", " + trackStatusColumn(track) + " = $3"
+		}
+	}
 
-func (r *PostgreSQL) UpdateRequestStatus(ctx context.Context, id int64, status int) error {
 	query := `
 		UPDATE requests 
-		SET status_id = $1 
-		WHERE id = $2
-	`
+		` + set + `
+		WHERE id = $4 RETURNING user_id`
 
-	_, err := r.repository.Pool().Exec(ctx, query, status, id)
+	var userIDToMail int64
+	err = tx.QueryRow(ctx, query, userID, time.Now(), int(changes[0].To), statuses.RequestID).Scan(&userIDToMail)
 	if err != nil {
-		return fmt.Errorf("error updating document: %w", err)
+		return userIDToMail, fmt.Errorf("error updating requests: %w", err)
 	}
 
-	return nil
-}
+	if err := insertWorkflowHistory(ctx, tx, statuses.RequestID, changes...); err != nil {
+		return userIDToMail, err
+	}
 
-func (r *PostgreSQL) UpdateRequestStatusByFileNumber(ctx context.Context, fileNumber string, status int) error {
-	query := `
-		UPDATE requests 
-		SET status_id = $1 
-		WHERE file_number = $2
-	`
+	if err := insertOutboxMessages(ctx, tx, statuses.RequestID, msgs); err != nil {
+		return userIDToMail, err
+	}
 
-	_, err := r.repository.Pool().Exec(ctx, query, status, fileNumber)
-	if err != nil {
-		return fmt.Errorf("error updating request status: %w", err)
+	if err = tx.Commit(ctx); err != nil {
+		return userIDToMail, fmt.Errorf("error committing transaction: %v", err)
 	}
 
-	return nil
+	return userIDToMail, nil
 }
 
 // helpers
@@ -1091,7 +1187,8 @@ func (r *PostgreSQL) GetRequestByExpCode(ctx context.Context, expCode string) (*
 			COALESCE(r.abl_debt, '') as abl_debt,
 			COALESCE(r.selected_activities, ARRAY[]::integer[]) as selected_activities,
 			COALESCE(r.estimated_time, 0) as estimated_time,
-			COALESCE(r.insurance, FALSE) as insurance
+			COALESCE(r.insurance, FALSE) as insurance,
+			COALESCE(r.form_data, '{}'::jsonb) as form_data
 		FROM public.requests r 
 		LEFT JOIN properties p ON p.property_id = r.property_id 
 		LEFT JOIN abl a ON a.abl_id = p.abl_id 
@@ -1126,6 +1223,7 @@ func (r *PostgreSQL) GetRequestByExpCode(ctx context.Context, expCode string) (*
 		&req.SelectedActivities,
 		&req.EstimatedTime,
 		&req.Insurance,
+		&req.FormData,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -1144,11 +1242,12 @@ func (r *PostgreSQL) GetRequestByExpCode(ctx context.Context, expCode string) (*
 			filename
 		FROM documents
 		INNER JOIN document_types ON documents.document_type_id = document_types.id
-		WHERE code = $1 
+		WHERE code = $1 AND NOT superseded
 		AND document_types.description IS NOT NULL 
-		AND document_types.description != ''`
+		AND document_types.description != ''
+		AND document_type_id != $2`
 
-	rows, err := r.repository.Pool().Query(ctx, documentQuery, expCode)
+	rows, err := r.repository.Pool().Query(ctx, documentQuery, expCode, int(domain.DocumentTypeMessageAttachment))
 	if err != nil {
 		return nil, fmt.Errorf("error querying documents: %w", err)
 	}
@@ -1184,19 +1283,35 @@ func (r *PostgreSQL) GetRequestByExpCode(ctx context.Context, expCode string) (*
 	return request, nil
 }
 
-func (r *PostgreSQL) UpdateUserRequest(ctx context.Context, req *domain.Request) (string, string, error) {
+func (r *PostgreSQL) UpdateUserRequest(ctx context.Context, req *domain.Request, msgs ...domain.OutboxMessage) (string, string, error) {
 	if req == nil {
 		return "", "", fmt.Errorf("nil request")
 	}
 
+	userID, err := r.findUserIDFromCuil(ctx, req.Cuil)
+	if err != nil {
+		return "", "", err
+	}
+
 	tx, err := r.repository.Pool().Begin(ctx)
 	if err != nil {
 		return "", "", fmt.Errorf("error starting transaction: %v", err)
 	}
 	defer tx.Rollback(ctx)
 
+	statuses, err := lockRequestStatuses(ctx, tx, req.FileNumber)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Solo se puede reenviar una solicitud con observaciones
+	change, err := statuses.Transition(domain.StatusTrackGlobal, domain.RequestStatusRequiresChanges, domain.RequestStatusPending, userID, "")
+	if err != nil {
+		return "", "", err
+	}
+
 	reqDataModel := transport.ToCreateRequestDataModel(req)
-	reqDataModel.StatusID = 1
+	reqDataModel.StatusID = int(change.To)
 
 	const query = `
         UPDATE requests 
@@ -1209,7 +1324,7 @@ func (r *PostgreSQL) UpdateUserRequest(ctx context.Context, req *domain.Request)
             estimated_time = $5,
             insurance = $6,
             selected_activities = $7
-        WHERE file_number = $8 
+        WHERE id = $8 
         RETURNING observations, observations_tasks, id`
 
 	var observations sql.NullString
@@ -1223,7 +1338,7 @@ func (r *PostgreSQL) UpdateUserRequest(ctx context.Context, req *domain.Request)
 		reqDataModel.EstimatedTime,
 		reqDataModel.Insurance,
 		pq.Array(reqDataModel.SelectedActivities),
-		req.FileNumber,
+		statuses.RequestID,
 	).Scan(&observations, &observationsTasks, &reqID)
 
 	if err != nil {
@@ -1233,6 +1348,23 @@ func (r *PostgreSQL) UpdateUserRequest(ctx context.Context, req *domain.Request)
 		return "", "", fmt.Errorf("%w: %v", transport.ErrUpdateRequest, err)
 	}
 
+	if err := insertWorkflowHistory(ctx, tx, reqID, change); err != nil {
+		return "", "", err
+	}
+
+	// Se descartan los archivos de un reenvío anterior que no llegó a enviarse al expediente
+	if err := deleteRequestFiles(ctx, tx, reqID); err != nil {
+		return "", "", err
+	}
+
+	if err := insertRequestFiles(ctx, tx, reqID, req.Documents); err != nil {
+		return "", "", err
+	}
+
+	if err := insertOutboxMessages(ctx, tx, reqID, msgs); err != nil {
+		return "", "", err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return "", "", fmt.Errorf("error committing transaction: %v", err)
 	}
@@ -1249,31 +1381,50 @@ func (r *PostgreSQL) UpdateVerificationStatus(ctx context.Context, fileNumber st
 	}
 	defer tx.Rollback(ctx)
 
-	statusID := 1
+	statuses, err := lockRequestStatuses(ctx, tx, fileNumber)
+	if err != nil {
+		return err
+	}
+
+	// Los circuitos observados vuelven a quedar pendientes de verificación
+	var changes []domain.StatusChange
+	for _, track := range []domain.StatusTrack{domain.StatusTrackTasks, domain.StatusTrackProperty} {
+		if statuses.Of(track) != domain.RequestStatusObserved {
+			continue
+		}
+
+		change, err := statuses.Transition(track, domain.RequestStatusObserved, domain.RequestStatusPending, 0, "")
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change)
+	}
 
 	const query = `
         UPDATE requests 
         SET 
-			status_id_tasks = CASE WHEN status_id_tasks = 5 THEN $1 ELSE status_id_tasks END,
-        	status_id_property = CASE WHEN status_id_property = 5 THEN $2 ELSE status_id_property END
-        WHERE file_number = $3 
-        RETURNING id, user_id`
-
-	var requestID int64
-	var userID int64
-	err = tx.QueryRow(ctx, query,
-		statusID,
-		statusID,
-		fileNumber,
-	).Scan(&requestID, &userID)
-
+			status_id_tasks = CASE WHEN status_id_tasks = $1 THEN $2 ELSE status_id_tasks END,
+        	status_id_property = CASE WHEN status_id_property = $1 THEN $2 ELSE status_id_property END
+        WHERE id = $3`
+
+	_, err = tx.Exec(ctx, query,
+		int(domain.RequestStatusObserved),
+		int(domain.RequestStatusPending),
+		statuses.RequestID,
+	)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return fmt.Errorf("%w: request not found", transport.ErrUpdateRequest)
-		}
 		return fmt.Errorf("%w: %v", transport.ErrUpdateRequest, err)
 	}
 
+	if err := insertWorkflowHistory(ctx, tx, statuses.RequestID, changes...); err != nil {
+		return err
+	}
+
+	// Los archivos reenviados ya están en el expediente
+	if err := deleteRequestFiles(ctx, tx, statuses.RequestID); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return fmt.Errorf("error committing transaction: %v", err)
 	}
@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+type DocumentVersionDataModel struct {
+	ID           int64          `db:"id"`
+	Type         int            `db:"document_type_id"`
+	Description  sql.NullString `db:"description"`
+	FileID       string         `db:"file_id"`
+	Filename     sql.NullString `db:"filename"`
+	Version      int            `db:"version"`
+	UploadedBy   sql.NullString `db:"uploaded_by"`
+	Superseded   bool           `db:"superseded"`
+	Verified     bool           `db:"is_verified"`
+	Observations sql.NullString `db:"observations"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+func ToDocumentVersionDomain(model *DocumentVersionDataModel) domain.DocumentVersion {
+	return domain.DocumentVersion{
+		Document: domain.Document{
+			ID:           model.ID,
+			Type:         model.Type,
+			Title:        model.Description.String,
+			GedoCode:     model.FileID,
+			Verified:     model.Verified,
+			Observations: model.Observations.String,
+		},
+		Version:    model.Version,
+		Filename:   model.Filename.String,
+		UploadedBy: model.UploadedBy.String,
+		Superseded: model.Superseded,
+		CreatedAt:  model.CreatedAt,
+	}
+}
@@ -12,4 +12,7 @@ type DocumentModel struct {
 	Type        int            `json:"document_type_id"`
 	Activities  []string       `json:"activities"`
 	Status      string         `json:"status"`
+	// Decisión del verificador: aceptado, o rechazado con el motivo en observations
+	Verified     bool           `json:"is_verified"`
+	Observations sql.NullString `json:"observations"`
 }
@@ -0,0 +1,59 @@
+package transport
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+type DraftDataModel struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	DraftData []byte    `db:"draft_data"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type DraftDocumentDataModel struct {
+	RequestID int64     `db:"request_id"`
+	Type      int       `db:"document_type_id"`
+	Name      string    `db:"name"`
+	Content   string    `db:"content"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ToDraftData serializa los campos del borrador para guardarlos en requests.draft_data
+func ToDraftData(fields map[string]any) ([]byte, error) {
+	if len(fields) == 0 {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(fields)
+}
+
+func ToDraftDomain(model *DraftDataModel) (domain.Draft, error) {
+	fields := map[string]any{}
+	if len(model.DraftData) > 0 {
+		if err := json.Unmarshal(model.DraftData, &fields); err != nil {
+			return domain.Draft{}, err
+		}
+	}
+
+	return domain.Draft{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		Fields:    fields,
+		Documents: []domain.DraftDocument{},
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
+
+func ToDraftDocumentDomain(model *DraftDocumentDataModel) domain.DraftDocument {
+	return domain.DraftDocument{
+		Type:      domain.DocumentTypeID(model.Type),
+		Name:      model.Name,
+		Content:   model.Content,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// RequestEventPayload es el contenido de la notificación de un cambio de estado en el canal request_events
+type RequestEventPayload struct {
+	RequestID        int64     `json:"request_id"`
+	Type             string    `json:"type"`
+	OwnerID          int64     `json:"owner_id"`
+	FileNumber       string    `json:"file_number"`
+	Track            string    `json:"track"`
+	PreviousStatusID int       `json:"previous_status_id"`
+	NewStatusID      int       `json:"new_status_id"`
+	HasObservations  bool      `json:"has_observations"`
+	ChangeDate       time.Time `json:"change_date"`
+}
+
+func ToRequestEvent(payload *RequestEventPayload) domain.RequestEvent {
+	track := domain.StatusTrack(payload.Track)
+	from := domain.RequestStatus(payload.PreviousStatusID)
+	to := domain.RequestStatus(payload.NewStatusID)
+
+	// Las notificaciones del file-manager no informan el tipo y se clasifican por el cambio de estado
+	eventType := domain.TimelineEventType(payload.Type)
+	if eventType == "" {
+		eventType = domain.TimelineEventTypeFor(track, from, to)
+	}
+
+	return domain.RequestEvent{
+		RequestID:       payload.RequestID,
+		OwnerID:         payload.OwnerID,
+		FileNumber:      payload.FileNumber,
+		Type:            eventType,
+		Track:           track,
+		PreviousStatus:  from,
+		Status:          to,
+		HasObservations: payload.HasObservations,
+		Date:            payload.ChangeDate,
+	}
+}
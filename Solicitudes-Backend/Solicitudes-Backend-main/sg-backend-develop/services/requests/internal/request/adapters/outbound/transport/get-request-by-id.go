@@ -1,14 +1,25 @@
 package transport
 
-import "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+import (
+	"encoding/json"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
 
 func ToRequestDomain(model *RequestDataModel) *domain.Request {
 	if model == nil {
 		return nil
 	}
 
+	var formData map[string]any
+	if len(model.FormData) > 0 {
+		_ = json.Unmarshal(model.FormData, &formData)
+	}
+
 	return &domain.Request{
 		UserID:             model.UserID,
+		RequestTypeID:      model.RequestTypeID,
+		FormData:           formData,
 		UserType:           domain.UserType(model.UserType.String),
 		PropertyID:         model.PropertyID,
 		ABLDebt:            model.ABLDebt,
@@ -0,0 +1,60 @@
+package transport
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+type WorkflowHistoryDataModel struct {
+	ChangeDate          time.Time      `db:"change_date"`
+	Track               string         `db:"track"`
+	PreviousStatusID    sql.NullInt32  `db:"previous_status_id"`
+	PreviousStatusLabel sql.NullString `db:"previous_status_name"`
+	NewStatusID         int            `db:"new_status_id"`
+	NewStatusLabel      sql.NullString `db:"new_status_name"`
+	UserID              sql.NullInt64  `db:"user_id"`
+	ActorName           sql.NullString `db:"actor_name"`
+	Observations        sql.NullString `db:"observations"`
+}
+
+type DocumentHistoryDataModel struct {
+	CreatedAt   time.Time      `db:"created_at"`
+	Type        int            `db:"document_type_id"`
+	Description sql.NullString `db:"description"`
+	Name        sql.NullString `db:"name"`
+}
+
+func ToWorkflowTimelineEvent(model *WorkflowHistoryDataModel) domain.TimelineEvent {
+	track := domain.StatusTrack(model.Track)
+	from := domain.RequestStatus(model.PreviousStatusID.Int32)
+	to := domain.RequestStatus(model.NewStatusID)
+
+	return domain.TimelineEvent{
+		Date:                model.ChangeDate,
+		Type:                domain.TimelineEventTypeFor(track, from, to),
+		Track:               track,
+		PreviousStatus:      from,
+		PreviousStatusLabel: model.PreviousStatusLabel.String,
+		Status:              to,
+		StatusLabel:         model.NewStatusLabel.String,
+		ActorUserID:         model.UserID.Int64,
+		ActorName:           model.ActorName.String,
+		Observations:        model.Observations.String,
+	}
+}
+
+func ToDocumentTimelineEvent(model *DocumentHistoryDataModel) domain.TimelineEvent {
+	name := model.Name.String
+	if name == "" {
+		name = model.Description.String
+	}
+
+	return domain.TimelineEvent{
+		Date:         model.CreatedAt,
+		Type:         domain.TimelineEventDocumentAdded,
+		DocumentType: model.Type,
+		DocumentName: name,
+	}
+}
@@ -0,0 +1,46 @@
+package transport
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+type MessageDataModel struct {
+	ID         int64          `db:"id"`
+	RequestID  int64          `db:"request_id"`
+	UserID     int64          `db:"user_id"`
+	AuthorName sql.NullString `db:"author_name"`
+	Content    string         `db:"content"`
+	Read       bool           `db:"read"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+type MessageAttachmentDataModel struct {
+	MessageID  int64          `db:"message_id"`
+	DocumentID int64          `db:"document_id"`
+	Name       sql.NullString `db:"name"`
+	Content    string         `db:"content"`
+}
+
+func ToMessageDomain(model *MessageDataModel) domain.Message {
+	return domain.Message{
+		ID:          model.ID,
+		RequestID:   model.RequestID,
+		UserID:      model.UserID,
+		AuthorName:  model.AuthorName.String,
+		Content:     model.Content,
+		Attachments: []domain.MessageAttachment{},
+		Read:        model.Read,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+func ToMessageAttachmentDomain(model *MessageAttachmentDataModel) domain.MessageAttachment {
+	return domain.MessageAttachment{
+		DocumentID: model.DocumentID,
+		Name:       model.Name.String,
+		Content:    model.Content,
+	}
+}
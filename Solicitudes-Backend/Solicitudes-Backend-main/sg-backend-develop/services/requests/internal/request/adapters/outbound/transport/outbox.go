@@ -0,0 +1,34 @@
+package transport
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+type OutboxDataModel struct {
+	ID            int64          `db:"id"`
+	RequestID     int64          `db:"request_id"`
+	Action        string         `db:"action"`
+	Payload       []byte         `db:"payload"`
+	Status        string         `db:"status"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+func ToOutboxMessageDomain(model *OutboxDataModel) domain.OutboxMessage {
+	return domain.OutboxMessage{
+		ID:            model.ID,
+		RequestID:     model.RequestID,
+		Action:        domain.OutboxAction(model.Action),
+		Payload:       model.Payload,
+		Status:        domain.OutboxStatus(model.Status),
+		Attempts:      model.Attempts,
+		LastError:     model.LastError.String,
+		NextAttemptAt: model.NextAttemptAt,
+		CreatedAt:     model.CreatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+type RequestTypeDataModel struct {
+	ID          int            `db:"id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	Active      bool           `db:"is_active"`
+	Definition  []byte         `db:"definition"`
+}
+
+// RequestTypeDefinitionModel es el formato de la columna request_types.definition
+type RequestTypeDefinitionModel struct {
+	Fields    []FormFieldModel        `json:"fields"`
+	Documents []RequiredDocumentModel `json:"documents"`
+	Tracks    []string                `json:"tracks"`
+	Templates []string                `json:"templates"`
+	// allow, warn o block. Vacío equivale a warn
+	DuplicatePolicy string `json:"duplicate_policy"`
+}
+
+type FormFieldModel struct {
+	Name      string   `json:"name"`
+	Label     string   `json:"label"`
+	Kind      string   `json:"kind"`
+	Required  bool     `json:"required"`
+	Min       *float64 `json:"min"`
+	Max       *float64 `json:"max"`
+	MaxLength int      `json:"max_length"`
+	Pattern   string   `json:"pattern"`
+	Options   []string `json:"options"`
+}
+
+type RequiredDocumentModel struct {
+	Type         int      `json:"type"`
+	Label        string   `json:"label"`
+	UserTypes    []string `json:"user_types"`
+	RequiredWhen string   `json:"required_when"`
+	Optional     bool     `json:"optional"`
+	Track        string   `json:"track"`
+	MimeTypes    []string `json:"mime_types"`
+}
+
+func ToRequestTypeDomain(model *RequestTypeDataModel) (*domain.RequestType, error) {
+	var definition RequestTypeDefinitionModel
+	if len(model.Definition) > 0 {
+		if err := json.Unmarshal(model.Definition, &definition); err != nil {
+			return nil, fmt.Errorf("invalid definition of request type %d: %w", model.ID, err)
+		}
+	}
+
+	fields := make([]domain.FormField, len(definition.Fields))
+	for i, f := range definition.Fields {
+		kind := domain.FormFieldKind(f.Kind)
+		if kind == "" {
+			kind = domain.FormFieldText
+		}
+		fields[i] = domain.FormField{
+			Name:      f.Name,
+			Label:     f.Label,
+			Kind:      kind,
+			Required:  f.Required,
+			Min:       f.Min,
+			Max:       f.Max,
+			MaxLength: f.MaxLength,
+			Pattern:   f.Pattern,
+			Options:   f.Options,
+		}
+	}
+
+	documents := make([]domain.RequiredDocument, len(definition.Documents))
+	for i, d := range definition.Documents {
+		userTypes := make([]domain.UserType, len(d.UserTypes))
+		for j, ut := range d.UserTypes {
+			userTypes[j] = domain.UserType(ut)
+		}
+		documents[i] = domain.RequiredDocument{
+			Type:         domain.DocumentTypeID(d.Type),
+			Label:        d.Label,
+			UserTypes:    userTypes,
+			RequiredWhen: d.RequiredWhen,
+			Optional:     d.Optional,
+			Track:        domain.StatusTrack(d.Track),
+			MimeTypes:    d.MimeTypes,
+		}
+	}
+
+	tracks := make([]domain.StatusTrack, len(definition.Tracks))
+	for i, track := range definition.Tracks {
+		tracks[i] = domain.StatusTrack(track)
+	}
+
+	return &domain.RequestType{
+		ID:              model.ID,
+		Name:            model.Name,
+		Description:     model.Description.String,
+		Active:          model.Active,
+		Fields:          fields,
+		Documents:       documents,
+		Tracks:          tracks,
+		Templates:       definition.Templates,
+		DuplicatePolicy: domain.DuplicatePolicy(definition.DuplicatePolicy),
+	}, nil
+}
@@ -2,6 +2,7 @@ package transport
 
 import (
 	"database/sql"
+	"encoding/json"
 	"strconv"
 	"strings"
 	"time"
@@ -23,6 +24,7 @@ type RequestDataModel struct {
 	UserID               int64               `db:"user_id"`
 	UserType             sql.NullString      `db:"user_type"`
 	RequestTypeID        int                 `db:"request_type_id"`
+	RequestTypeName      string              `db:"request_type_name"`
 	PropertyID           int64               `db:"property_id"`
 	StatusID             int                 `db:"status_id"`
 	StatusName           string              `db:"status_name"`
@@ -39,6 +41,7 @@ type RequestDataModel struct {
 	Insurance            bool                `db:"insurance"`
 	Observations         sql.NullString      `db:"observations"`
 	ObservationsTasks    sql.NullString      `db:"observations_tasks"`
+	FormData             []byte              `db:"form_data"`
 	Documents            []DocumentDataModel `db:"-"` // Usamos db:"-" para indicar que no es una columna en la tabla requests
 }
 
@@ -52,8 +55,22 @@ func ToCreateRequestDataModel(req *domain.Request) *RequestDataModel {
 		}
 	}
 
+	requestTypeID := req.RequestTypeID
+	if requestTypeID == 0 {
+		requestTypeID = domain.DefaultRequestTypeID
+	}
+
+	formData := []byte("{}")
+	if len(req.FormData) > 0 {
+		if data, err := json.Marshal(req.FormData); err == nil {
+			formData = data
+		}
+	}
+
 	return &RequestDataModel{
-		UserID: req.UserID,
+		UserID:        req.UserID,
+		RequestTypeID: requestTypeID,
+		FormData:      formData,
 		UserType: sql.NullString{
 			String: string(req.UserType),
 			Valid:  true,
@@ -74,6 +91,8 @@ func ToRequestDomainList(dataModels []RequestDataModel) []domain.Request {
 	for i, dm := range dataModels {
 		domainRequests[i] = domain.Request{
 			UserID:             dm.UserID,
+			RequestTypeID:      dm.RequestTypeID,
+			RequestTypeName:    dm.RequestTypeName,
 			StatusName:         dm.StatusName,
 			CreatedAt:          dm.CreatedAt,
 			PropertyID:         dm.PropertyID,
@@ -183,6 +202,9 @@ type RequestPayload struct {
 	Documents          []DocumentPayload `json:"documents"`
 	Observations       string            `json:"observations"`
 	ObservationsTasks  string            `json:"observations_tasks"`
+	RequestTypeID      int               `json:"request_type_id,omitempty"`
+	FormData           map[string]any    `json:"form_data,omitempty"`
+	Templates          []string          `json:"templates,omitempty"`
 }
 
 func ToRequestPayload(req *domain.Request) *RequestPayload {
@@ -227,7 +249,10 @@ func ToRequestPayload(req *domain.Request) *RequestPayload {
 			Street:    req.Address.Street,
 			Number:    req.Address.Number,
 		},
-		Insurance: req.Insurance,
-		Documents: docs,
+		Insurance:     req.Insurance,
+		Documents:     docs,
+		RequestTypeID: req.RequestTypeID,
+		FormData:      req.FormData,
+		Templates:     req.Templates,
 	}
 }
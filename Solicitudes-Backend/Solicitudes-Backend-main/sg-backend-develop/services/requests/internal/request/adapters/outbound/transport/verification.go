@@ -27,6 +27,32 @@ type VerificactionDataModel struct {
 	RequestStatus  string         `json:"request_status"`
 	DocumentID     string         `json:"document_id"`
 	DocumentType   string         `json:"document_type"`
+	Activities     []string       `json:"activities"`
+	SLA            SLADataModel   `json:"-"`
+}
+
+// SLADataModel columnas necesarias para calcular el vencimiento de la etapa de la solicitud
+type SLADataModel struct {
+	RequestTypeID       int
+	Status              int
+	StatusTasks         int
+	StatusProperty      int
+	VerificationStarted sql.NullTime
+	TracksUpdated       sql.NullTime
+	ChangesRequested    sql.NullTime
+}
+
+// Fields devuelve los destinos del Scan en el mismo orden que las columnas de la consulta
+func (m *SLADataModel) Fields() []interface{} {
+	return []interface{}{
+		&m.RequestTypeID,
+		&m.Status,
+		&m.StatusTasks,
+		&m.StatusProperty,
+		&m.VerificationStarted,
+		&m.TracksUpdated,
+		&m.ChangesRequested,
+	}
 }
 
 func ToVerificationDomain(v *VerificactionDataModel) *domain.Verification {
@@ -72,6 +98,20 @@ func ToVerificationListDomain(list []VerificactionDataModel) []domain.Verificati
 			RequesterFullName: fmt.Sprintf("%s %s", d.FirstName, d.LastName),
 			RequesterCuil:     d.CUIL,
 			RequesterAddress:  fmt.Sprintf("%s, %s", address, d.Locality.String),
+			Activities:        d.Activities,
+			RequestTypeID:     d.SLA.RequestTypeID,
+			Statuses: domain.RequestStatuses{
+				RequestID: d.ID,
+				Global:    domain.RequestStatus(d.SLA.Status),
+				Tasks:     domain.RequestStatus(d.SLA.StatusTasks),
+				Property:  domain.RequestStatus(d.SLA.StatusProperty),
+			},
+			Milestones: domain.SLAMilestones{
+				Submitted:           d.DeliveryDate,
+				VerificationStarted: d.SLA.VerificationStarted.Time,
+				TracksUpdated:       d.SLA.TracksUpdated.Time,
+				ChangesRequested:    d.SLA.ChangesRequested.Time,
+			},
 		})
 	}
 
@@ -82,10 +122,12 @@ func ToDocumentDomainList(list []DocumentModel) []domain.Document {
 	var documents []domain.Document
 	for _, d := range list {
 		documents = append(documents, domain.Document{
-			ID:       d.ID,
-			Type:     d.Type,
-			Title:    d.Description.String,
-			GedoCode: d.FileID,
+			ID:           d.ID,
+			Type:         d.Type,
+			Title:        d.Description.String,
+			GedoCode:     d.FileID,
+			Verified:     d.Verified,
+			Observations: d.Observations.String,
 		})
 	}
 
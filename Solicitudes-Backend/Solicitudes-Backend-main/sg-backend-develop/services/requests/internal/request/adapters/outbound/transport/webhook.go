@@ -0,0 +1,86 @@
+package transport
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+type WebhookSubscriptionDataModel struct {
+	ID         int64         `db:"id"`
+	Name       string        `db:"name"`
+	URL        string        `db:"url"`
+	Secret     string        `db:"secret"`
+	EventTypes []string      `db:"event_types"`
+	Active     bool          `db:"active"`
+	CreatedBy  sql.NullInt64 `db:"created_by"`
+	CreatedAt  time.Time     `db:"created_at"`
+	UpdatedAt  time.Time     `db:"updated_at"`
+}
+
+type WebhookDeliveryDataModel struct {
+	ID             int64          `db:"id"`
+	SubscriptionID int64          `db:"subscription_id"`
+	RequestID      int64          `db:"request_id"`
+	EventType      string         `db:"event_type"`
+	Payload        []byte         `db:"payload"`
+	Status         string         `db:"status"`
+	Attempts       int            `db:"attempts"`
+	LastStatusCode sql.NullInt32  `db:"last_status_code"`
+	LastError      sql.NullString `db:"last_error"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	RedeliveryOf   sql.NullInt64  `db:"redelivery_of"`
+	CreatedAt      time.Time      `db:"created_at"`
+}
+
+// ToWebhookEventTypes convierte los tipos de evento para guardarlos en webhook_subscriptions.event_types
+func ToWebhookEventTypes(eventTypes []domain.TimelineEventType) []string {
+	values := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		values[i] = string(eventType)
+	}
+	return values
+}
+
+func ToWebhookSubscriptionDomain(model *WebhookSubscriptionDataModel) domain.WebhookSubscription {
+	eventTypes := make([]domain.TimelineEventType, len(model.EventTypes))
+	for i, eventType := range model.EventTypes {
+		eventTypes[i] = domain.TimelineEventType(eventType)
+	}
+
+	return domain.WebhookSubscription{
+		ID:         model.ID,
+		Name:       model.Name,
+		URL:        model.URL,
+		Secret:     model.Secret,
+		EventTypes: eventTypes,
+		Active:     model.Active,
+		CreatedBy:  model.CreatedBy.Int64,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
+
+func ToWebhookDeliveryDomain(model *WebhookDeliveryDataModel) domain.WebhookDelivery {
+	delivery := domain.WebhookDelivery{
+		ID:             model.ID,
+		SubscriptionID: model.SubscriptionID,
+		RequestID:      model.RequestID,
+		EventType:      domain.TimelineEventType(model.EventType),
+		Payload:        model.Payload,
+		Status:         domain.WebhookDeliveryStatus(model.Status),
+		Attempts:       model.Attempts,
+		LastStatusCode: int(model.LastStatusCode.Int32),
+		LastError:      model.LastError.String,
+		NextAttemptAt:  model.NextAttemptAt,
+		RedeliveryOf:   model.RedeliveryOf.Int64,
+		CreatedAt:      model.CreatedAt,
+	}
+	if model.DeliveredAt.Valid {
+		delivery.DeliveredAt = &model.DeliveredAt.Time
+	}
+
+	return delivery
+}
@@ -0,0 +1,100 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	ports "github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+// LocalUploadStore guarda cada subida en un archivo del directorio configurado. Sirve para desarrollo
+// y para una única instancia del servicio
+type LocalUploadStore struct {
+	dir   string
+	locks sync.Map // un *sync.Mutex por subida
+}
+
+func NewLocalUploadStore(dir string) (ports.UploadStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating upload directory: %w", err)
+	}
+
+	return &LocalUploadStore{dir: dir}, nil
+}
+
+func (s *LocalUploadStore) Append(ctx context.Context, id string, offset int64, data []byte) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	lock, _ := s.locks.LoadOrStore(id, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening upload file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading upload file: %w", err)
+	}
+	if info.Size() < offset {
+		return fmt.Errorf("%w: %d bytes stored, got %d", domain.ErrUploadOffsetMismatch, info.Size(), offset)
+	}
+
+	// Lo guardado después del offset es un fragmento que no llegó a registrarse y se reemplaza
+	if err := file.Truncate(offset); err != nil {
+		return fmt.Errorf("error truncating upload file: %w", err)
+	}
+	if _, err := file.WriteAt(data, offset); err != nil {
+		return fmt.Errorf("error writing upload file: %w", err)
+	}
+
+	return file.Sync()
+}
+
+func (s *LocalUploadStore) Read(ctx context.Context, id string) ([]byte, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading upload file: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *LocalUploadStore) Delete(ctx context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error deleting upload file: %w", err)
+	}
+	s.locks.Delete(id)
+
+	return nil
+}
+
+// path arma la ruta del archivo. El id llega en la URL, así que se valida antes de usarlo
+func (s *LocalUploadStore) path(id string) (string, error) {
+	if !domain.ValidUploadID(id) {
+		return "", fmt.Errorf("%w: %s", domain.ErrUploadNotFound, id)
+	}
+	return filepath.Join(s.dir, id), nil
+}
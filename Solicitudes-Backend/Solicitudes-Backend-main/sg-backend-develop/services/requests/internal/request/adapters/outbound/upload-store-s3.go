@@ -0,0 +1,145 @@
+package outbound
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+
+	sdkaws "github.com/teamcubation/sg-backend/pkg/aws/localstack"
+	sdkdefs "github.com/teamcubation/sg-backend/pkg/aws/localstack/defs"
+
+	domain "github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	ports "github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+// S3UploadStore guarda cada fragmento como un objeto uploads/<id>/<offset>. S3 no permite agregar datos
+// a un objeto, así que el archivo se arma al leerlo concatenando los fragmentos en orden
+type S3UploadStore struct {
+	client sdkdefs.S3Client
+	bucket string
+}
+
+func NewS3UploadStore(ctx context.Context, bucket string) (ports.UploadStore, error) {
+	stack, err := sdkaws.Bootstrap()
+	if err != nil {
+		return nil, fmt.Errorf("bootstrap error: %w", err)
+	}
+
+	client := stack.NewS3Client()
+	if err := client.EnsureBucket(ctx, bucket); err != nil {
+		return nil, err
+	}
+
+	return &S3UploadStore{client: client, bucket: bucket}, nil
+}
+
+func (s *S3UploadStore) Append(ctx context.Context, id string, offset int64, data []byte) error {
+	chunks, err := s.chunks(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Los fragmentos desde el offset no llegaron a registrarse y se reemplazan
+	var stored int64
+	var stale []string
+	for _, chunk := range chunks {
+		if chunk.offset >= offset {
+			stale = append(stale, chunk.key)
+			continue
+		}
+		stored = max(stored, chunk.offset+chunk.size)
+	}
+	if stored != offset {
+		return fmt.Errorf("%w: %d bytes stored, got %d", domain.ErrUploadOffsetMismatch, stored, offset)
+	}
+
+	if len(stale) > 0 {
+		if err := s.client.DeleteObjects(ctx, s.bucket, stale); err != nil {
+			return err
+		}
+	}
+
+	return s.client.PutObject(ctx, s.bucket, chunkKey(id, offset), data)
+}
+
+func (s *S3UploadStore) Read(ctx context.Context, id string) ([]byte, error) {
+	chunks, err := s.chunks(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	for _, chunk := range chunks {
+		if chunk.offset != int64(len(data)) {
+			return nil, fmt.Errorf("upload %s is missing data at offset %d", id, len(data))
+		}
+
+		content, err := s.client.GetObject(ctx, s.bucket, chunk.key)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, content...)
+	}
+
+	return data, nil
+}
+
+func (s *S3UploadStore) Delete(ctx context.Context, id string) error {
+	chunks, err := s.chunks(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		keys[i] = chunk.key
+	}
+
+	return s.client.DeleteObjects(ctx, s.bucket, keys)
+}
+
+type uploadChunk struct {
+	key    string
+	offset int64
+	size   int64
+}
+
+// chunks devuelve los fragmentos guardados de la subida, ordenados por offset
+func (s *S3UploadStore) chunks(ctx context.Context, id string) ([]uploadChunk, error) {
+	if !domain.ValidUploadID(id) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUploadNotFound, id)
+	}
+
+	objects, err := s.client.ListObjects(ctx, s.bucket, chunkPrefix(id))
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]uploadChunk, 0, len(objects))
+	for _, obj := range objects {
+		offset, err := strconv.ParseInt(path.Base(obj.Key), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upload chunk %s", obj.Key)
+		}
+		chunks = append(chunks, uploadChunk{key: obj.Key, offset: offset, size: obj.Size})
+	}
+
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].offset < chunks[j].offset
+	})
+
+	return chunks, nil
+}
+
+func chunkPrefix(id string) string {
+	return "uploads/" + id + "/"
+}
+
+func chunkKey(id string, offset int64) string {
+	return fmt.Sprintf("%s%020d", chunkPrefix(id), offset)
+}
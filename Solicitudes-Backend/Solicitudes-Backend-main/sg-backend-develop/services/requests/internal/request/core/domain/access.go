@@ -0,0 +1,39 @@
+package domain
+
+import "errors"
+
+// Permission names stored in the permissions table
+const (
+	PermissionVerify   = "request:verify"
+	PermissionValidate = "request:validate"
+	PermissionReadAll  = "request:read_all"
+	PermissionAssign   = "request:assign"
+	PermissionWebhooks = "webhook:manage"
+)
+
+var (
+	ErrRequestNotFound     = errors.New("request not found")
+	ErrRequestAccessDenied = errors.New("access to request denied")
+)
+
+// UserAccess identifies the user calling the API and the roles and permissions assigned to them
+type UserAccess struct {
+	UserID      int64
+	Roles       []string
+	Permissions []string
+}
+
+// HasPermission indicates whether any of the user roles grants the permission
+func (a UserAccess) HasPermission(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRead indicates whether the user can read a request owned by ownerID
+func (a UserAccess) CanRead(ownerID int64) bool {
+	return a.UserID == ownerID || a.HasPermission(PermissionReadAll)
+}
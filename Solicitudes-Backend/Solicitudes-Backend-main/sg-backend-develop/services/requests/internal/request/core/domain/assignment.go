@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAssignmentConflict  = errors.New("request is assigned to another verifier")
+	ErrTrackNotPending     = errors.New("track is not pending verification")
+	ErrAssigneeNotVerifier = errors.New("assignee cannot verify requests")
+)
+
+// Assignment is the lease a verifier holds on one of the verification tracks of a request.
+// Once expired the track can be claimed by any verifier
+type Assignment struct {
+	RequestID  int64
+	FileNumber string
+	Track      StatusTrack
+	UserID     int64
+	AssignedBy int64
+	ClaimedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+// Claim asks for the assignment of one or more verification tracks of a request
+type Claim struct {
+	FileNumber string
+	Tracks     []StatusTrack
+	UserID     int64 // verifier the tracks are assigned to
+	AssignedBy int64
+	Lease      time.Duration
+	Force      bool // reassign the tracks even if another verifier holds them
+}
+
+// AssignmentOptions configures the duration of the assignments
+type AssignmentOptions struct {
+	Lease time.Duration
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxBulkItems is the maximum number of requests of a bulk action
+const MaxBulkItems = 100
+
+var (
+	ErrInvalidBulkAction = errors.New("invalid bulk action")
+	ErrMissingDocument   = errors.New("missing document for the request")
+)
+
+// BulkVerification applies the same decision on a verification track to several requests. Each
+// request needs its own verification document
+type BulkVerification struct {
+	Cuil             string
+	VerificationType string
+	Observations     string
+	Reference        string
+	FileNumbers      []string
+	Documents        map[string]string // verification document by file number
+}
+
+// BulkValidation validates or rejects several requests. Each validated request needs its own
+// authorization document
+type BulkValidation struct {
+	Cuil         string
+	IsValid      bool
+	Observations string // reason of the rejection
+	FileNumbers  []string
+	Documents    map[string]string // authorization document by file number
+}
+
+// BulkResult is the outcome of a bulk action for one request, Err is nil when it was applied
+type BulkResult struct {
+	FileNumber string
+	Err        error
+}
+
+// NormalizeFileNumbers trims the file numbers and removes the repeated ones, keeping their order
+func NormalizeFileNumbers(fileNumbers []string) ([]string, error) {
+	seen := make(map[string]bool, len(fileNumbers))
+	normalized := make([]string, 0, len(fileNumbers))
+	for _, fileNumber := range fileNumbers {
+		fileNumber = strings.TrimSpace(fileNumber)
+		if fileNumber == "" {
+			return nil, fmt.Errorf("%w: empty file number", ErrInvalidBulkAction)
+		}
+		if !seen[fileNumber] {
+			seen[fileNumber] = true
+			normalized = append(normalized, fileNumber)
+		}
+	}
+
+	if len(normalized) == 0 || len(normalized) > MaxBulkItems {
+		return nil, fmt.Errorf("%w: between 1 and %d file numbers are required", ErrInvalidBulkAction, MaxBulkItems)
+	}
+
+	return normalized, nil
+}
+
+// Request returns the verification of one of the requests
+func (b *BulkVerification) Request(fileNumber string) (*VerifiedRequest, error) {
+	document := b.Documents[fileNumber]
+	if document == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingDocument, fileNumber)
+	}
+
+	return &VerifiedRequest{
+		FileNumber:                fileNumber,
+		Cuil:                      b.Cuil,
+		VerificationType:          b.VerificationType,
+		Observations:              b.Observations,
+		FinalVerificationDocument: document,
+		Reference:                 b.Reference,
+	}, nil
+}
+
+// Request returns the validation of one of the requests. A rejection doesn't need a document
+func (b *BulkValidation) Request(fileNumber string) (*ValidateRequest, error) {
+	document := b.Documents[fileNumber]
+	if b.IsValid && document == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingDocument, fileNumber)
+	}
+
+	return &ValidateRequest{
+		FileNumber:   fileNumber,
+		Cuil:         b.Cuil,
+		IsValid:      b.IsValid,
+		FileContent:  document,
+		Observations: b.Observations,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DocumentDecisionStatus is the decision of the verifier on a document uploaded by the citizen
+type DocumentDecisionStatus string
+
+const (
+	DocumentDecisionPending  DocumentDecisionStatus = "pending"
+	DocumentDecisionAccepted DocumentDecisionStatus = "accepted"
+	DocumentDecisionRejected DocumentDecisionStatus = "rejected"
+)
+
+// MaxDocumentReasonLength is the maximum length of the reason of a rejected document
+const MaxDocumentReasonLength = 1000
+
+var (
+	ErrInvalidDocumentDecision = errors.New("invalid document decision")
+	ErrUnexpectedDocument      = errors.New("document was not rejected")
+)
+
+// DocumentDecision accepts or rejects one of the documents of the request. A rejection needs a reason
+type DocumentDecision struct {
+	DocumentID int64
+	Status     DocumentDecisionStatus
+	Reason     string
+}
+
+// Decision returns the decision of the verifier on the document. The reason of a rejected document
+// is kept in its observations
+func (d Document) Decision() DocumentDecisionStatus {
+	switch {
+	case d.Verified:
+		return DocumentDecisionAccepted
+	case d.Observations != "":
+		return DocumentDecisionRejected
+	default:
+		return DocumentDecisionPending
+	}
+}
+
+// RejectedDocuments returns the documents the citizen has to upload again
+func RejectedDocuments(documents []Document) []Document {
+	rejected := make([]Document, 0, len(documents))
+	for _, doc := range documents {
+		if doc.Decision() == DocumentDecisionRejected {
+			rejected = append(rejected, doc)
+		}
+	}
+	return rejected
+}
+
+// ApplyDocumentDecisions applies the decisions of a verification to the documents of the request and
+// returns the documents of the track with their new state. Every document of the track has to be
+// decided; the documents accepted in a previous verification keep their decision
+func (t *RequestType) ApplyDocumentDecisions(track StatusTrack, documents []Document, decisions []DocumentDecision) ([]Document, error) {
+	byID := make(map[int64]int, len(documents))
+	for i, doc := range documents {
+		byID[doc.ID] = i
+	}
+
+	decided := make(map[int64]bool, len(decisions))
+	for i := range decisions {
+		decision := &decisions[i]
+
+		idx, ok := byID[decision.DocumentID]
+		if !ok {
+			return nil, fmt.Errorf("%w: document %d is not part of the request", ErrInvalidDocumentDecision, decision.DocumentID)
+		}
+		if decided[decision.DocumentID] {
+			return nil, fmt.Errorf("%w: document %d is decided twice", ErrInvalidDocumentDecision, decision.DocumentID)
+		}
+		decided[decision.DocumentID] = true
+
+		doc := &documents[idx]
+		docTrack, ok := t.DocumentTrack(DocumentTypeID(doc.Type))
+		if !ok {
+			return nil, fmt.Errorf("%w: document %d (%s) is not uploaded by the citizen", ErrInvalidDocumentDecision, doc.ID, doc.Title)
+		}
+		if docTrack != track {
+			return nil, fmt.Errorf("%w: document %d (%s) is verified in the %s track", ErrInvalidDocumentDecision, doc.ID, doc.Title, docTrack)
+		}
+
+		decision.Reason = strings.TrimSpace(decision.Reason)
+		if len(decision.Reason) > MaxDocumentReasonLength {
+			return nil, fmt.Errorf("%w: the reason must have at most %d characters", ErrInvalidDocumentDecision, MaxDocumentReasonLength)
+		}
+
+		switch decision.Status {
+		case DocumentDecisionAccepted:
+			decision.Reason = ""
+			doc.Verified, doc.Observations = true, ""
+		case DocumentDecisionRejected:
+			if decision.Reason == "" {
+				return nil, fmt.Errorf("%w: document %d (%s) is rejected without a reason", ErrInvalidDocumentDecision, doc.ID, doc.Title)
+			}
+			doc.Verified, doc.Observations = false, decision.Reason
+		default:
+			return nil, fmt.Errorf("%w: unknown decision %q", ErrInvalidDocumentDecision, decision.Status)
+		}
+	}
+
+	var trackDocuments []Document
+	for _, doc := range documents {
+		if docTrack, ok := t.DocumentTrack(DocumentTypeID(doc.Type)); !ok || docTrack != track {
+			continue
+		}
+		if doc.Decision() == DocumentDecisionPending {
+			return nil, fmt.Errorf("%w: missing decision for document %d (%s)", ErrInvalidDocumentDecision, doc.ID, doc.Title)
+		}
+		trackDocuments = append(trackDocuments, doc)
+	}
+
+	return trackDocuments, nil
+}
+
+// DocumentObservations adds the reasons of the rejected documents to the observations of the verifier.
+// The track is observed when the result is not empty
+func DocumentObservations(observations string, documents []Document) string {
+	lines := []string{}
+	if observations = strings.TrimSpace(observations); observations != "" {
+		lines = append(lines, observations)
+	}
+
+	for _, doc := range RejectedDocuments(documents) {
+		lines = append(lines, fmt.Sprintf("- %s: %s", doc.Title, doc.Observations))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// CheckResubmission checks that a resubmission uploads again every rejected document and nothing else.
+// Without rejected documents the whole track was observed and any document can be replaced
+func CheckResubmission(rejected []Document, uploaded []DocumentRequest) error {
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	expected := make(map[DocumentTypeID]Document, len(rejected))
+	for _, doc := range rejected {
+		expected[DocumentTypeID(doc.Type)] = doc
+	}
+
+	attached := make(map[DocumentTypeID]bool, len(uploaded))
+	for _, doc := range uploaded {
+		if doc.Content == "" {
+			continue
+		}
+		if _, ok := expected[doc.Type]; !ok {
+			return fmt.Errorf("%w: %d", ErrUnexpectedDocument, doc.Type)
+		}
+		attached[doc.Type] = true
+	}
+
+	var missing []string
+	for _, doc := range rejected {
+		if !attached[DocumentTypeID(doc.Type)] {
+			missing = append(missing, fmt.Sprintf("%d (%s)", doc.Type, doc.Title))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingDocument, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
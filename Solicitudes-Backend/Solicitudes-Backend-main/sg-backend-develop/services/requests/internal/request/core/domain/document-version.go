@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrDocumentVersionNotFound = errors.New("document version not found")
+
+// DocumentVersion is one of the uploads of a document type in a request. Uploading the document again
+// supersedes the current version instead of overwriting it, so each version keeps its GDE number and
+// the decision of the verifier
+type DocumentVersion struct {
+	Document
+	Version    int
+	Filename   string
+	UploadedBy string
+	Superseded bool
+	CreatedAt  time.Time
+}
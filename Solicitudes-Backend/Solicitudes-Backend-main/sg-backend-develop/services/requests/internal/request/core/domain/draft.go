@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrDraftNotFound      = errors.New("draft not found")
+	ErrEmptyDraftDocument = errors.New("draft document must have content")
+)
+
+// DraftDocument is a file attached to a draft, one per document type
+type DraftDocument struct {
+	Type      DocumentTypeID
+	Name      string
+	Content   string // only loaded when the draft is submitted
+	UpdatedAt time.Time
+}
+
+// Draft is a request saved by the citizen to be completed and submitted later. Fields holds the values
+// loaded so far, with the same names as the payload used to create a request
+type Draft struct {
+	ID        int64
+	UserID    int64
+	Fields    map[string]any
+	Documents []DraftDocument
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DraftOptions configures the cleanup of the drafts that were not submitted
+type DraftOptions struct {
+	TTL             time.Duration
+	CleanupInterval time.Duration
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DuplicatePolicy is what to do when a new request overlaps active requests of the same property
+type DuplicatePolicy string
+
+const (
+	DuplicatePolicyAllow DuplicatePolicy = "allow" // no check
+	DuplicatePolicyWarn  DuplicatePolicy = "warn"  // the request is created and the citizen is warned
+	DuplicatePolicyBlock DuplicatePolicy = "block" // the request is rejected
+)
+
+var ErrDuplicateRequest = errors.New("there are active requests for the same property and activities")
+
+// TerminalStatuses are the statuses of the requests that no longer count as active
+var TerminalStatuses = []RequestStatus{RequestStatusFailed, RequestStatusDraft, RequestStatusWithdrawn}
+
+// DuplicateQuery looks for active requests of the property with some of the activities whose
+// estimated time window overlaps the window of the new request
+type DuplicateQuery struct {
+	PropertyID       int64
+	Activities       []int
+	Start            time.Time
+	End              time.Time
+	ExcludeRequestID int64
+	Cuil             string // applicant of the new request
+}
+
+// NewDuplicateQuery builds the query for a new request, starting now and lasting its estimated time
+func NewDuplicateQuery(req *Request, now time.Time) DuplicateQuery {
+	return DuplicateQuery{
+		PropertyID:       req.PropertyID,
+		Activities:       req.SelectedActivities,
+		Start:            now,
+		End:              EstimatedEnd(now, req.EstimatedTime),
+		ExcludeRequestID: req.ID,
+		Cuil:             req.Cuil,
+	}
+}
+
+// EstimatedEnd returns the end of the window of a request, which lasts at least one day
+func EstimatedEnd(start time.Time, estimatedDays int64) time.Time {
+	return start.AddDate(0, 0, int(max(estimatedDays, 1)))
+}
+
+// RelatedRequest is an active request of the same property that overlaps another one
+type RelatedRequest struct {
+	ID            int64
+	FileNumber    string
+	Status        string
+	CreatedAt     time.Time
+	EstimatedTime int64
+	EndDate       time.Time
+	Activities    []string // activities shared by both requests
+	SameApplicant bool
+}
+
+// Policy returns the duplicate policy of the request type, warning by default
+func (t *RequestType) Policy() DuplicatePolicy {
+	if t.DuplicatePolicy == "" {
+		return DuplicatePolicyWarn
+	}
+	return t.DuplicatePolicy
+}
+
+// CheckDuplicates applies the duplicate policy of the request type to the overlapping requests
+func (t *RequestType) CheckDuplicates(related []RelatedRequest) error {
+	if len(related) == 0 || t.Policy() != DuplicatePolicyBlock {
+		return nil
+	}
+
+	fileNumbers := make([]string, len(related))
+	for i, r := range related {
+		fileNumbers[i] = r.FileNumber
+	}
+	return fmt.Errorf("%w: %s", ErrDuplicateRequest, strings.Join(fileNumbers, ", "))
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// RequestEventsChannel is the Postgres channel where the status changes of the requests are notified
+const RequestEventsChannel = "request_events"
+
+const (
+	RequestEventsHeartbeat      = 25 * time.Second // keeps idle streams open behind proxies
+	RequestEventsReconnectDelay = 5 * time.Second  // wait before listening again after losing the connection
+	RequestEventsBuffer         = 16               // events kept per subscriber before dropping them
+)
+
+// RequestEvent is a status change of a request, published when the change is committed. It
+// doesn't carry the observations, only whether the change has them
+type RequestEvent struct {
+	RequestID       int64
+	OwnerID         int64 // user that submitted the request
+	FileNumber      string
+	Type            TimelineEventType
+	Track           StatusTrack
+	PreviousStatus  RequestStatus
+	Status          RequestStatus
+	HasObservations bool
+	Date            time.Time
+}
+
+// VisibleToCitizen indicates whether the citizen that submitted the request receives the event
+func (e RequestEvent) VisibleToCitizen() bool {
+	return e.Track == StatusTrackGlobal && e.Type.VisibleToCitizen()
+}
+
+// RequestEventFilter selects the events a subscriber receives
+type RequestEventFilter func(RequestEvent) bool
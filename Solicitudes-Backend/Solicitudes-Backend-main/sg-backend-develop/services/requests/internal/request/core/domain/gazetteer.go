@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// GazetteerRefreshInterval is how long the street gazetteer is cached before being reloaded
+const GazetteerRefreshInterval = 15 * time.Minute
+
+type StreetAliasKind string
+
+const (
+	StreetAliasKindAlias      StreetAliasKind = "alias"
+	StreetAliasKindHistorical StreetAliasKind = "historical" // name the street had before being renamed
+)
+
+// StreetAbbreviation is a usual abbreviation in street names, such as "av" for "avenida".
+// Both are normalized: lowercase words without accents or punctuation
+type StreetAbbreviation struct {
+	Abbreviation string
+	Expansion    string
+}
+
+// StreetAlias is another name a street is known by. Both names are normalized
+type StreetAlias struct {
+	CanonicalName string
+	Alias         string
+	Kind          StreetAliasKind
+}
+
+// StreetGazetteer resolves the street names typed by the citizens to the names used to search
+// the properties: abbreviations are expanded and aliases and old names replaced by the current name.
+// A nil gazetteer only normalizes the spacing of the name
+type StreetGazetteer struct {
+	abbreviations map[string]string
+	aliases       map[string]string
+}
+
+func NewStreetGazetteer(abbreviations []StreetAbbreviation, aliases []StreetAlias) *StreetGazetteer {
+	g := &StreetGazetteer{
+		abbreviations: make(map[string]string, len(abbreviations)),
+		aliases:       make(map[string]string, len(aliases)),
+	}
+
+	for _, abbr := range abbreviations {
+		g.abbreviations[abbr.Abbreviation] = abbr.Expansion
+	}
+
+	// Aliases are indexed by their expanded form, so "pte peron" and "presidente peron" match the same alias
+	for _, alias := range aliases {
+		g.aliases[g.Expand(alias.Alias)] = g.Expand(alias.CanonicalName)
+	}
+
+	return g
+}
+
+// Expand replaces the abbreviations of a normalized street name by their expansion
+func (g *StreetGazetteer) Expand(name string) string {
+	words := strings.Fields(name)
+	if g != nil {
+		for i, word := range words {
+			if expansion, ok := g.abbreviations[word]; ok {
+				words[i] = expansion
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Canonicalize expands a normalized street name and, if it is an alias or an old name of a street,
+// returns the current name of the street
+func (g *StreetGazetteer) Canonicalize(name string) string {
+	expanded := g.Expand(name)
+	if g != nil {
+		if canonical, ok := g.aliases[expanded]; ok {
+			return canonical
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// IdempotencyHeader is the header the clients use to retry a mutating request safely
+const IdempotencyHeader = "Idempotency-Key"
+
+const (
+	MaxIdempotencyKeyLength = 255
+	// IdempotencyLockTimeout is how long a request being processed holds its key. After that the
+	// request is considered lost and a retry processes it again
+	IdempotencyLockTimeout = 2 * time.Minute
+)
+
+var (
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was used with a different request")
+	ErrIdempotencyInProgress = errors.New("a request with the same idempotency key is being processed")
+)
+
+// IdempotencyRecord is a mutating request identified by an idempotency key and, once completed,
+// the response returned to the client. Scope isolates the keys of each user and endpoint
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	Fingerprint string // hash of the request body
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	Completed   bool
+	ExpiresAt   time.Time
+}
+
+// Validate checks the key sent by the client
+func (r *IdempotencyRecord) Validate() error {
+	if r.Key == "" || len(r.Key) > MaxIdempotencyKeyLength {
+		return ErrInvalidIdempotencyKey
+	}
+	return nil
+}
+
+// Replay checks that a stored record can answer a retry of the request: the body must be the same
+// and the original request must have finished
+func (r *IdempotencyRecord) Replay(fingerprint string) error {
+	if r.Fingerprint != fingerprint {
+		return ErrIdempotencyKeyReused
+	}
+	if !r.Completed {
+		return ErrIdempotencyInProgress
+	}
+	return nil
+}
+
+// IdempotencyOptions configures how long the responses are kept
+type IdempotencyOptions struct {
+	TTL             time.Duration
+	CleanupInterval time.Duration
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Document type of the files attached to a message
+const DocumentTypeMessageAttachment DocumentTypeID = 19
+
+var ErrEmptyMessage = errors.New("message must have content or attachments")
+
+// MessageAttachment is a file attached to a message, stored as a document of the request
+type MessageAttachment struct {
+	DocumentID int64
+	Name       string
+	Content    string
+}
+
+// Message is an entry of the conversation between the citizen and the staff about a request
+type Message struct {
+	ID          int64
+	RequestID   int64
+	UserID      int64
+	AuthorName  string
+	Content     string
+	Attachments []MessageAttachment
+	Read        bool // read by the user listing the thread
+	CreatedAt   time.Time
+}
+
+// Validate checks that the message is not empty
+func (m *Message) Validate() error {
+	m.Content = strings.TrimSpace(m.Content)
+	if m.Content == "" && len(m.Attachments) == 0 {
+		return ErrEmptyMessage
+	}
+	return nil
+}
+
+// Uploads returns the attachments of the message as files uploaded by the citizen
+func (m *Message) Uploads() []Upload {
+	uploads := make([]Upload, len(m.Attachments))
+	for i, attachment := range m.Attachments {
+		uploads[i] = Upload{Type: DocumentTypeMessageAttachment, Name: attachment.Name, Content: attachment.Content}
+	}
+	return uploads
+}
+
+// MessageThread is the ordered conversation of a request
+type MessageThread struct {
+	RequestID  int64
+	UserID     int64
+	FileNumber string
+	Messages   []Message
+}
+
+// Unread returns the number of messages not yet read by the user listing the thread
+func (t *MessageThread) Unread() int {
+	unread := 0
+	for _, msg := range t.Messages {
+		if !msg.Read {
+			unread++
+		}
+	}
+	return unread
+}
+
+// CitizenView hides the names of the staff members that took part in the conversation
+func (t *MessageThread) CitizenView() *MessageThread {
+	view := &MessageThread{
+		RequestID:  t.RequestID,
+		UserID:     t.UserID,
+		FileNumber: t.FileNumber,
+		Messages:   make([]Message, len(t.Messages)),
+	}
+
+	for i, msg := range t.Messages {
+		if msg.UserID != t.UserID {
+			msg.AuthorName = ""
+		}
+		view.Messages[i] = msg
+	}
+
+	return view
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "time"
+
+type OutboxAction string
+type OutboxStatus string
+
+// Outbox actions
+const (
+	OutboxActionCreateRecord             OutboxAction = "create_record"
+	OutboxActionSendCreatedEmail         OutboxAction = "send_created_email"
+	OutboxActionSendVerificationDocument OutboxAction = "send_verification_document"
+	OutboxActionSendObservationsEmail    OutboxAction = "send_observations_email"
+	OutboxActionSendValidationDocument   OutboxAction = "send_validation_document"
+	OutboxActionSendValidatedEmail       OutboxAction = "send_validated_email"
+	OutboxActionUpdateRecordDocuments    OutboxAction = "update_record_documents"
+	OutboxActionSendResubmittedEmail     OutboxAction = "send_resubmitted_email"
+	OutboxActionSendMessageEmail         OutboxAction = "send_message_email"
+	OutboxActionSendWithdrawalDocument   OutboxAction = "send_withdrawal_document"
+	OutboxActionSendWithdrawnEmail       OutboxAction = "send_withdrawn_email"
+)
+
+// Outbox status constants
+const (
+	OutboxStatusPending    OutboxStatus = "pending"
+	OutboxStatusProcessing OutboxStatus = "processing"
+	OutboxStatusDone       OutboxStatus = "done"
+	OutboxStatusDead       OutboxStatus = "dead"
+)
+
+// OutboxMessage represents a side effect persisted together with the request
+// and delivered asynchronously by the outbox worker
+type OutboxMessage struct {
+	ID            int64
+	RequestID     int64
+	Action        OutboxAction
+	Payload       []byte
+	Status        OutboxStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
+
+// OutboxOptions configures the outbox worker
+type OutboxOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+	RateLimit    int // maximum deliveries per second, without limit when 0
+}
+
+// Outbox payloads
+
+type EmailPayload struct {
+	FileNumber   string
+	Email        string
+	Observations string
+	RequestType  string
+}
+
+type VerificationDocumentPayload struct {
+	FileNumber       string
+	Content          string
+	Reference        string
+	VerificationType string
+	Username         string
+	HasObservations  bool
+}
+
+type ValidationDocumentPayload struct {
+	FileNumber string
+	Content    string
+	Username   string
+}
+
+type WithdrawalDocumentPayload struct {
+	FileNumber string
+	Content    string
+	Username   string
+}
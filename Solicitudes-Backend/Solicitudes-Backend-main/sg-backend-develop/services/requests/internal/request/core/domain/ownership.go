@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+type OwnershipResult string
+
+const (
+	OwnershipMatch         OwnershipResult = "match"
+	OwnershipPossibleMatch OwnershipResult = "possible_match" // needs a manual review by the verifier
+	OwnershipNoMatch       OwnershipResult = "no_match"
+)
+
+type OwnershipMethod string
+
+const (
+	OwnershipMethodCUIT OwnershipMethod = "cuit"
+	OwnershipMethodName OwnershipMethod = "name"
+)
+
+// Thresholds of the name matching score
+const (
+	OwnershipMatchScore         = 0.85
+	OwnershipPossibleMatchScore = 0.5
+	ownershipTokenSimilarity    = 0.75 // minimum similarity for two name tokens to be considered the same
+)
+
+// nameParticles are left out of the name matching: "Pérez de García" and "Pérez García" are the same name
+var nameParticles = map[string]bool{
+	"de": true, "del": true, "la": true, "las": true, "los": true, "y": true, "e": true, "vda": true, "viuda": true,
+}
+
+// PropertyOwner is one of the owners registered for the ABL account of a property
+type PropertyOwner struct {
+	Name string
+	CUIT string // empty when the registry doesn't have it
+	Role string // primary or secondary
+}
+
+// OwnershipClaimant is the person that claims to own the property
+type OwnershipClaimant struct {
+	FirstName string
+	LastName  string
+	CUIL      string
+}
+
+// OwnershipCheck is the outcome of matching a claimant against the owners of a property
+type OwnershipCheck struct {
+	Result       OwnershipResult
+	Method       OwnershipMethod
+	Score        float64 // confidence between 0 and 1
+	MatchedOwner string
+	Explanation  string
+}
+
+// MatchOwnership compares the claimant with the owners of the property. The CUIL is compared first
+// against the owners that have a CUIT. Otherwise the names are compared token by token, tolerating
+// middle names, a different order, typos and married names; a name that matches an owner with a
+// different CUIT is left for manual review. The best scored owner wins
+func MatchOwnership(claimant OwnershipClaimant, owners []PropertyOwner) OwnershipCheck {
+	best := OwnershipCheck{
+		Result:      OwnershipNoMatch,
+		Method:      OwnershipMethodName,
+		Explanation: "La propiedad no tiene titulares registrados",
+	}
+	if len(owners) == 0 {
+		return best
+	}
+	best.Explanation = "El nombre no coincide con ningún titular"
+
+	cuil := digitsOnly(claimant.CUIL)
+	claimantTokens := nameTokens(claimant.LastName + " " + claimant.FirstName)
+
+	for _, owner := range owners {
+		cuit := digitsOnly(owner.CUIT)
+		if cuit != "" && cuit == cuil {
+			return OwnershipCheck{
+				Result:       OwnershipMatch,
+				Method:       OwnershipMethodCUIT,
+				Score:        1,
+				MatchedOwner: owner.Name,
+				Explanation:  fmt.Sprintf("El CUIL coincide con el CUIT del titular %s", owner.Name),
+			}
+		}
+
+		score, matched := nameScore(claimantTokens, nameTokens(owner.Name))
+		if score <= best.Score {
+			continue
+		}
+
+		best = OwnershipCheck{
+			Result:       OwnershipNoMatch,
+			Method:       OwnershipMethodName,
+			Score:        score,
+			MatchedOwner: owner.Name,
+		}
+		switch {
+		case score >= OwnershipMatchScore && matched >= 2:
+			best.Result = OwnershipMatch
+		case score >= OwnershipPossibleMatchScore && matched >= 1:
+			best.Result = OwnershipPossibleMatch
+		}
+		best.Explanation = fmt.Sprintf("Coinciden %d de %d palabras del nombre con el titular %s (%.0f%%)",
+			matched, len(claimantTokens), owner.Name, score*100)
+
+		// A different CUIT contradicts the name, so at most it is left for manual review
+		if cuit != "" && cuil != "" && best.Result != OwnershipNoMatch {
+			best.Result = OwnershipPossibleMatch
+			best.Explanation += ", pero su CUIT no coincide con el CUIL"
+		}
+	}
+
+	return best
+}
+
+// nameScore pairs each token of a with the most similar unpaired token of b. The score weights the
+// coverage of the shorter name, so middle names missing on one side lower it only slightly
+func nameScore(a, b []string) (float64, int) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, 0
+	}
+
+	used := make([]bool, len(b))
+	var total float64
+	var matched int
+	for _, ta := range a {
+		bestSim, bestIdx := 0.0, -1
+		for i, tb := range b {
+			if used[i] {
+				continue
+			}
+			if sim := tokenSimilarity(ta, tb); sim > bestSim {
+				bestSim, bestIdx = sim, i
+			}
+		}
+		if bestIdx >= 0 && bestSim >= ownershipTokenSimilarity {
+			used[bestIdx] = true
+			total += bestSim
+			matched++
+		}
+	}
+
+	coverage := total / float64(min(len(a), len(b)))
+	dice := 2 * total / float64(len(a)+len(b))
+
+	return 0.7*coverage + 0.3*dice, matched
+}
+
+// tokenSimilarity is 1 minus the edit distance relative to the longest token. Short tokens must be equal
+func tokenSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if min(len(ra), len(rb)) < 4 {
+		return 0
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// nameTokens lowercases the name, removes the accents and splits it in words, leaving out the particles
+func nameTokens(name string) []string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	clean, _, _ := transform.String(t, strings.ToLower(name))
+
+	words := strings.FieldsFunc(clean, func(r rune) bool { return r < 'a' || r > 'z' })
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if !nameParticles[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+func digitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
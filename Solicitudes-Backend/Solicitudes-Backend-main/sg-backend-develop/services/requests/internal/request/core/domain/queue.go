@@ -0,0 +1,70 @@
+package domain
+
+import "time"
+
+type QueueSortField string
+
+// Queue sort keys
+const (
+	QueueSortCreatedAt  QueueSortField = "created_at"
+	QueueSortFileNumber QueueSortField = "file_number"
+	QueueSortStatus     QueueSortField = "status"
+	QueueSortStreet     QueueSortField = "street"
+	QueueSortRequester  QueueSortField = "requester"
+)
+
+// Queue pagination defaults
+const (
+	DefaultQueuePageSize = 20
+	MaxQueuePageSize     = 100
+)
+
+// QueueQuery filters, sorts and paginates the verification and validation queues.
+// Zero values mean no filter
+type QueueQuery struct {
+	Page           int
+	PageSize       int
+	Status         RequestStatus
+	StatusTasks    RequestStatus
+	StatusProperty RequestStatus
+	CreatedFrom    time.Time // inclusive
+	CreatedTo      time.Time // exclusive
+	Street         string
+	Cuil           string
+	FileNumber     string
+	AssignedTo     int64 // requests with an active assignment of the user
+	SortBy         QueueSortField
+	SortDesc       bool
+}
+
+// Normalize applies the default page, page size and sort key
+func (q *QueueQuery) Normalize() {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PageSize < 1 {
+		q.PageSize = DefaultQueuePageSize
+	}
+	if q.PageSize > MaxQueuePageSize {
+		q.PageSize = MaxQueuePageSize
+	}
+
+	switch q.SortBy {
+	case QueueSortCreatedAt, QueueSortFileNumber, QueueSortStatus, QueueSortStreet, QueueSortRequester:
+	default:
+		q.SortBy = QueueSortCreatedAt
+	}
+}
+
+// Offset returns the number of rows to skip for the current page
+func (q QueueQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// VerificationPage is a page of a queue together with the total of matching requests
+type VerificationPage struct {
+	Items    []Verification
+	Total    int64
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,316 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FormFieldKind string
+
+// Request type used when the citizen does not choose one ("Aviso de Obras")
+const DefaultRequestTypeID = 1
+
+// Form field kinds
+const (
+	FormFieldText    FormFieldKind = "text"
+	FormFieldNumber  FormFieldKind = "number"
+	FormFieldBoolean FormFieldKind = "boolean"
+	FormFieldDate    FormFieldKind = "date"
+	FormFieldSelect  FormFieldKind = "select"
+	FormFieldIntList FormFieldKind = "int_list"
+)
+
+// Form field names of the construction notice, stored in dedicated columns of the requests table
+const (
+	FieldSelectedActivities = "selected_activities"
+	FieldProjectDescription = "project_description"
+	FieldEstimatedTime      = "estimated_time"
+	FieldInsurance          = "insurance"
+	FieldABLDebt            = "abl_debt"
+	FieldCommonZone         = "common_zone"
+)
+
+var (
+	ErrRequestTypeNotFound = errors.New("request type not found")
+	ErrRequestTypeInactive = errors.New("request type is not active")
+	ErrInvalidForm         = errors.New("invalid request form")
+	ErrTrackNotUsed        = errors.New("verification track not used by the request type")
+)
+
+// FormField declares a field of the request form and how to validate it
+type FormField struct {
+	Name      string
+	Label     string
+	Kind      FormFieldKind
+	Required  bool
+	Min       *float64 // minimum value for numbers or number of items for lists
+	Max       *float64 // maximum value for numbers or number of items for lists
+	MaxLength int
+	Pattern   string
+	Options   []string
+}
+
+// RequiredDocument declares a document the citizen has to attach. UserTypes restricts the requirement to
+// some user types and RequiredWhen to requests where the given boolean field is true. Track is the
+// verification track that decides on the document, the property track when it is empty. MimeTypes are
+// the accepted formats, DefaultUploadTypes when it is empty
+type RequiredDocument struct {
+	Type         DocumentTypeID
+	Label        string
+	UserTypes    []UserType
+	RequiredWhen string
+	Optional     bool
+	Track        StatusTrack
+	MimeTypes    []string
+}
+
+// RequestType describes a procedure: the form, the documents, the verification tracks and the
+// file-manager templates used to generate the record documents
+type RequestType struct {
+	ID              int
+	Name            string
+	Description     string
+	Active          bool
+	Fields          []FormField
+	Documents       []RequiredDocument
+	Tracks          []StatusTrack
+	Templates       []string
+	DuplicatePolicy DuplicatePolicy
+}
+
+// UsesTrack indicates whether the request type is verified through the given track
+func (t *RequestType) UsesTrack(track StatusTrack) bool {
+	for _, tr := range t.Tracks {
+		if tr == track {
+			return true
+		}
+	}
+	return false
+}
+
+// DocumentTrack returns the verification track that decides on the documents of the given type, and
+// false when the citizen doesn't upload documents of that type
+func (t *RequestType) DocumentTrack(docType DocumentTypeID) (StatusTrack, bool) {
+	for _, doc := range t.Documents {
+		if doc.Type != docType {
+			continue
+		}
+		if doc.Track == "" {
+			return StatusTrackProperty, true
+		}
+		return doc.Track, true
+	}
+	return "", false
+}
+
+// UploadTypes returns the MIME types accepted for each document of the request type. The document types
+// it doesn't declare keep the defaults of AllowedUploadTypes
+func (t *RequestType) UploadTypes() UploadTypes {
+	allowed := AllowedUploadTypes.clone()
+	for _, doc := range t.Documents {
+		if len(doc.MimeTypes) > 0 {
+			allowed[doc.Type] = doc.MimeTypes
+		} else if _, ok := allowed[doc.Type]; !ok {
+			allowed[doc.Type] = DefaultUploadTypes
+		}
+	}
+	return allowed
+}
+
+// InitialTrackStatuses returns the status of the verification tracks of a new request. The tracks
+// not used by the request type start approved so that they never block the validation
+func (t *RequestType) InitialTrackStatuses() (tasks, property RequestStatus) {
+	tasks, property = RequestStatusApproved, RequestStatusApproved
+	if t.UsesTrack(StatusTrackTasks) {
+		tasks = RequestStatusPending
+	}
+	if t.UsesTrack(StatusTrackProperty) {
+		property = RequestStatusPending
+	}
+	return tasks, property
+}
+
+// Validate checks the form values and the attached documents of the request against the request type
+func (t *RequestType) Validate(req *Request) error {
+	if !t.Active {
+		return fmt.Errorf("%w: %s", ErrRequestTypeInactive, t.Name)
+	}
+
+	values := req.FormValues()
+
+	var problems []string
+	for _, field := range t.Fields {
+		if err := field.Validate(values[field.Name]); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	attached := make(map[DocumentTypeID]bool, len(req.Documents))
+	for _, doc := range req.Documents {
+		if doc.Content != "" {
+			attached[doc.Type] = true
+		}
+	}
+
+	for _, doc := range t.Documents {
+		if doc.isRequired(req.UserType, values) && !attached[doc.Type] {
+			problems = append(problems, fmt.Sprintf("missing document %d (%s)", doc.Type, doc.Label))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidForm, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (d RequiredDocument) isRequired(userType UserType, values map[string]any) bool {
+	if d.Optional {
+		return false
+	}
+
+	if len(d.UserTypes) > 0 {
+		matches := false
+		for _, ut := range d.UserTypes {
+			if strings.EqualFold(string(ut), string(userType)) {
+				matches = true
+				break
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+
+	if d.RequiredWhen != "" {
+		enabled, _ := values[d.RequiredWhen].(bool)
+		return enabled
+	}
+
+	return true
+}
+
+// Validate checks a value of the form, as decoded from JSON or taken from the request columns
+func (f FormField) Validate(value any) error {
+	if isEmptyValue(value) {
+		if f.Required {
+			return fmt.Errorf("%s is required", f.Name)
+		}
+		return nil
+	}
+
+	switch f.Kind {
+	case FormFieldNumber:
+		number, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("%s must be a number", f.Name)
+		}
+		return f.validateRange(number, "value")
+	case FormFieldBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", f.Name)
+		}
+	case FormFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a date", f.Name)
+		}
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return fmt.Errorf("%s must be a date with format YYYY-MM-DD", f.Name)
+		}
+	case FormFieldSelect:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be one of %s", f.Name, strings.Join(f.Options, ", "))
+		}
+		for _, option := range f.Options {
+			if option == text {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s", f.Name, strings.Join(f.Options, ", "))
+	case FormFieldIntList:
+		items, ok := toIntList(value)
+		if !ok {
+			return fmt.Errorf("%s must be a list of integers", f.Name)
+		}
+		if f.Required && len(items) == 0 {
+			return fmt.Errorf("%s is required", f.Name)
+		}
+		return f.validateRange(float64(len(items)), "number of items")
+	default:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a text", f.Name)
+		}
+		if f.MaxLength > 0 && len([]rune(text)) > f.MaxLength {
+			return fmt.Errorf("%s must have at most %d characters", f.Name, f.MaxLength)
+		}
+		if f.Pattern != "" {
+			matched, err := regexp.MatchString(f.Pattern, text)
+			if err != nil || !matched {
+				return fmt.Errorf("%s has an invalid format", f.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f FormField) validateRange(value float64, what string) error {
+	if f.Min != nil && value < *f.Min {
+		return fmt.Errorf("%s %s must be at least %v", f.Name, what, *f.Min)
+	}
+	if f.Max != nil && value > *f.Max {
+		return fmt.Errorf("%s %s must be at most %v", f.Name, what, *f.Max)
+	}
+	return nil
+}
+
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	}
+	return false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		number, err := strconv.ParseFloat(v, 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+func toIntList(value any) ([]int, bool) {
+	switch v := value.(type) {
+	case []int:
+		return v, true
+	case []any:
+		items := make([]int, 0, len(v))
+		for _, item := range v {
+			number, ok := toFloat(item)
+			if !ok || number != float64(int(number)) {
+				return nil, false
+			}
+			items = append(items, int(number))
+		}
+		return items, true
+	}
+	return nil, false
+}
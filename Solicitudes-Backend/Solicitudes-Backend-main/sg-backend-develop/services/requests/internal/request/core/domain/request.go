@@ -7,9 +7,10 @@ type UserType string
 
 // DocumentRequest represents a document in the domain
 type DocumentRequest struct {
-	Name    string
-	Type    DocumentTypeID
-	Content string
+	Name     string
+	Type     DocumentTypeID
+	Content  string
+	UploadID string // resumable upload with the content, instead of sending it inline
 }
 
 // Address represents an address in the domain
@@ -27,19 +28,25 @@ type VerifiedRequest struct {
 	FinalVerificationDocument string
 	Reference                 string
 	SelectedActivities        []int
+	Documents                 []DocumentDecision // decisions on the documents of the track, optional
 }
 
 type ValidateRequest struct {
-	FileNumber  string
-	Cuil        string
-	IsValid     bool
-	FileContent string
+	FileNumber   string
+	Cuil         string
+	IsValid      bool
+	FileContent  string
+	Observations string // reason of the rejection, shown to the citizen
 }
 
 // Request represents the main request entity in the domain
 type Request struct {
 	ID                 int64
 	UserID             int64
+	RequestTypeID      int
+	RequestTypeName    string
+	FormData           map[string]any
+	Templates          []string
 	PropertyID         int64
 	Cuil               string
 	Dni                string
@@ -66,6 +73,40 @@ type Request struct {
 	VerifyByTasks      string
 	VerifyDate         time.Time
 	VerifyDateTask     time.Time
+	Ownership          *OwnershipCheck  // requester against the owners of the property, for the verifiers
+	RelatedRequests    []RelatedRequest // active requests of the property overlapping this one
+}
+
+// FormValues returns the values of the request form. The fields of the construction notice are
+// stored in dedicated columns and take part in the validation as any other field
+func (r *Request) FormValues() map[string]any {
+	values := make(map[string]any, len(r.FormData)+6)
+	for name, value := range r.FormData {
+		values[name] = value
+	}
+
+	setDefault := func(name string, value any) {
+		if _, exists := values[name]; !exists {
+			values[name] = value
+		}
+	}
+
+	if r.SelectedActivities != nil {
+		setDefault(FieldSelectedActivities, r.SelectedActivities)
+	}
+	if r.EstimatedTime > 0 {
+		setDefault(FieldEstimatedTime, r.EstimatedTime)
+	}
+	if r.ProjectDesc != "" {
+		setDefault(FieldProjectDescription, r.ProjectDesc)
+	}
+	if r.ABLDebt != "" {
+		setDefault(FieldABLDebt, r.ABLDebt)
+	}
+	setDefault(FieldInsurance, r.Insurance)
+	setDefault(FieldCommonZone, r.CommonZone)
+
+	return values
 }
 
 // User type constants
@@ -84,3 +125,6 @@ type Suggestion struct {
 	AddrNum    int64
 	AblNum     int64
 }
+
+// MaxSuggestions caps the number of ranked address suggestions returned by the autocomplete.
+const MaxSuggestions = 20
@@ -0,0 +1,190 @@
+package domain
+
+import "time"
+
+type SLAStage string
+type SLAState string
+
+// SLA stages, each one with its own legal response time
+const (
+	SLAStageVerification    SLAStage = "verification"
+	SLAStageValidation      SLAStage = "validation"
+	SLAStageCitizenResponse SLAStage = "citizen_response"
+)
+
+// SLA states of a request in its current stage
+const (
+	SLAStateOnTime  SLAState = "on_time"
+	SLAStateAtRisk  SLAState = "at_risk"
+	SLAStateOverdue SLAState = "overdue"
+)
+
+// BusinessTimeZone is the time zone used to decide which day a date belongs to
+var BusinessTimeZone = time.FixedZone("ART", -3*60*60)
+
+// SLAPolicy is the response time, in business days, of a stage. A policy without request
+// type applies to every request type without a policy of its own
+type SLAPolicy struct {
+	RequestTypeID int
+	Stage         SLAStage
+	BusinessDays  int
+	AtRiskDays    int // the request is at risk when this many business days or fewer remain
+}
+
+// SLAMilestones are the dates at which the request entered each stage. Zero values mean
+// the request never entered the stage and the submission date is used instead
+type SLAMilestones struct {
+	Submitted           time.Time
+	VerificationStarted time.Time
+	TracksUpdated       time.Time
+	ChangesRequested    time.Time
+}
+
+// StageStart returns the date at which the request entered the stage
+func (m SLAMilestones) StageStart(stage SLAStage) time.Time {
+	var start time.Time
+	switch stage {
+	case SLAStageVerification:
+		start = m.VerificationStarted
+	case SLAStageValidation:
+		start = m.TracksUpdated
+	case SLAStageCitizenResponse:
+		start = m.ChangesRequested
+	}
+
+	if start.IsZero() {
+		return m.Submitted
+	}
+	return start
+}
+
+// SLA is the due date of the current stage of a request
+type SLA struct {
+	Stage         SLAStage
+	StartedAt     time.Time
+	DueDate       time.Time
+	RemainingDays int // business days left, negative once overdue
+	State         SLAState
+}
+
+// SLAStageOf returns the stage the request is waiting on. Requests being processed or
+// in a final status are not subject to an SLA
+func SLAStageOf(s RequestStatuses) (SLAStage, bool) {
+	switch s.Global {
+	case RequestStatusRequiresChanges:
+		return SLAStageCitizenResponse, true
+	case RequestStatusPending, RequestStatusVerified:
+		if s.Tasks == RequestStatusApproved && s.Property == RequestStatusApproved {
+			return SLAStageValidation, true
+		}
+		return SLAStageVerification, true
+	}
+	return "", false
+}
+
+// BusinessCalendar counts business days, skipping weekends and holidays
+type BusinessCalendar struct {
+	holidays map[string]bool
+}
+
+func NewBusinessCalendar(holidays []time.Time) *BusinessCalendar {
+	c := &BusinessCalendar{holidays: make(map[string]bool, len(holidays))}
+	for _, h := range holidays {
+		c.holidays[h.Format(time.DateOnly)] = true
+	}
+	return c
+}
+
+// IsBusinessDay indicates whether the day of t is neither a weekend nor a holiday
+func (c *BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	t = t.In(BusinessTimeZone)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[t.Format(time.DateOnly)]
+}
+
+// AddBusinessDays returns the date that is the given number of business days after start,
+// in the location of start
+func (c *BusinessCalendar) AddBusinessDays(start time.Time, days int) time.Time {
+	due := start.In(BusinessTimeZone)
+	for days > 0 {
+		due = due.AddDate(0, 0, 1)
+		if c.IsBusinessDay(due) {
+			days--
+		}
+	}
+	return due.In(start.Location())
+}
+
+// BusinessDaysBetween counts the business days after from up to and including to.
+// It returns a negative count when to is before from
+func (c *BusinessCalendar) BusinessDaysBetween(from, to time.Time) int {
+	if to.Before(from) {
+		return -c.BusinessDaysBetween(to, from)
+	}
+
+	from, to = startOfDay(from), startOfDay(to)
+	days := 0
+	for d := from.AddDate(0, 0, 1); !d.After(to); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			days++
+		}
+	}
+	return days
+}
+
+// Evaluate computes the due date of the stage started at startedAt and its state at now
+func (c *BusinessCalendar) Evaluate(policy SLAPolicy, startedAt, now time.Time) SLA {
+	due := c.AddBusinessDays(startedAt, policy.BusinessDays)
+	remaining := c.BusinessDaysBetween(now, due)
+
+	state := SLAStateOnTime
+	switch {
+	case now.After(due):
+		state = SLAStateOverdue
+		// Overdue on the due business day itself counts as one day late
+		if remaining == 0 {
+			remaining = -1
+		}
+	case remaining <= policy.AtRiskDays:
+		state = SLAStateAtRisk
+	}
+
+	return SLA{
+		Stage:         policy.Stage,
+		StartedAt:     startedAt,
+		DueDate:       due,
+		RemainingDays: remaining,
+		State:         state,
+	}
+}
+
+// SLAPolicies resolves the policy of a request type and stage
+type SLAPolicies []SLAPolicy
+
+// For returns the policy of the request type for the stage, falling back to the general one
+func (p SLAPolicies) For(requestTypeID int, stage SLAStage) (SLAPolicy, bool) {
+	var general *SLAPolicy
+	for i := range p {
+		if p[i].Stage != stage {
+			continue
+		}
+		if p[i].RequestTypeID == requestTypeID {
+			return p[i], true
+		}
+		if p[i].RequestTypeID == 0 {
+			general = &p[i]
+		}
+	}
+
+	if general == nil {
+		return SLAPolicy{}, false
+	}
+	return *general, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.In(BusinessTimeZone)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, BusinessTimeZone)
+}
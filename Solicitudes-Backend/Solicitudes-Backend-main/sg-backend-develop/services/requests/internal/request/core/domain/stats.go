@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// Number of activities listed in the most declared ranking
+const TopActivitiesLimit = 10
+
+// StatsQuery limits the statistics to the requests created in the range. Zero values mean no limit
+type StatsQuery struct {
+	From time.Time // inclusive
+	To   time.Time // exclusive
+}
+
+// Stats are the backoffice indicators of the requests created in a date range
+type Stats struct {
+	Total            int64
+	ByStatus         []StatCount
+	ByRequestType    []StatCount
+	Weekly           []WeeklyThroughput
+	ValidationTime   DurationStats
+	ObservationRates []ObservationRate
+	TopActivities    []StatCount
+}
+
+// StatCount is the number of requests of a status, request type or activity
+type StatCount struct {
+	ID    int64
+	Name  string
+	Count int64
+}
+
+// WeeklyThroughput is the number of requests filed and validated in a week starting on Monday
+type WeeklyThroughput struct {
+	WeekStart time.Time
+	Filed     int64
+	Validated int64
+}
+
+// DurationStats summarizes the time elapsed from the creation of the requests to their validation
+type DurationStats struct {
+	Count  int64
+	Median time.Duration
+	P90    time.Duration
+}
+
+// ObservationRate counts how many of the requests verified through a track were observed
+type ObservationRate struct {
+	Track            StatusTrack
+	Verifications    int64
+	Observations     int64
+	RequestsVerified int64
+	RequestsObserved int64
+}
+
+// Rate returns the share of the verified requests that were observed at least once
+func (r ObservationRate) Rate() float64 {
+	if r.RequestsVerified == 0 {
+		return 0
+	}
+	return float64(r.RequestsObserved) / float64(r.RequestsVerified)
+}
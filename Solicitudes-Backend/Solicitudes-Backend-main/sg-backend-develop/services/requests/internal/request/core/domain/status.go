@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+type RequestStatus int
+type StatusTrack string
+
+// Request status constants (ids of the request_status table)
+const (
+	RequestStatusNone            RequestStatus = 0
+	RequestStatusPending         RequestStatus = 1
+	RequestStatusFailed          RequestStatus = 2
+	RequestStatusApproved        RequestStatus = 3
+	RequestStatusValidated       RequestStatus = 4
+	RequestStatusObserved        RequestStatus = 5
+	RequestStatusRequiresChanges RequestStatus = 7
+	RequestStatusProcessing      RequestStatus = 8
+	RequestStatusVerified        RequestStatus = 9
+	RequestStatusDraft           RequestStatus = 10
+	RequestStatusWithdrawn       RequestStatus = 11
+)
+
+// Status track constants. The global track is stored in requests.status_id and
+// the verification tracks in status_id_tasks and status_id_property
+const (
+	StatusTrackGlobal   StatusTrack = "global"
+	StatusTrackTasks    StatusTrack = "tasks"
+	StatusTrackProperty StatusTrack = "property"
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// statusTransitions lists, for each track, the statuses reachable from a given status
+var statusTransitions = map[StatusTrack]map[RequestStatus][]RequestStatus{
+	StatusTrackGlobal: {
+		// Alta de la solicitud, queda procesando hasta que se genera el expediente
+		RequestStatusNone: {RequestStatusProcessing},
+		// Presentación de un borrador, sigue el mismo camino que el alta
+		RequestStatusDraft: {RequestStatusProcessing},
+		// Expediente generado o error al generarlo
+		RequestStatusProcessing: {RequestStatusPending, RequestStatusFailed},
+		// Verificación de un circuito, validación (o rechazo de la validación), error al reenviar la documentación o desistimiento
+		RequestStatusPending: {RequestStatusVerified, RequestStatusRequiresChanges, RequestStatusValidated, RequestStatusPending, RequestStatusWithdrawn},
+		// Verificación del otro circuito, envío del documento de verificación o desistimiento
+		RequestStatusVerified: {RequestStatusVerified, RequestStatusRequiresChanges, RequestStatusPending, RequestStatusWithdrawn},
+		// Verificación del otro circuito, reenvío de la solicitud por parte del ciudadano o desistimiento
+		RequestStatusRequiresChanges: {RequestStatusRequiresChanges, RequestStatusPending, RequestStatusWithdrawn},
+	},
+	StatusTrackTasks: {
+		RequestStatusPending:  {RequestStatusApproved, RequestStatusObserved},
+		RequestStatusObserved: {RequestStatusPending},
+		RequestStatusApproved: {RequestStatusPending},
+	},
+	StatusTrackProperty: {
+		RequestStatusPending:  {RequestStatusApproved, RequestStatusObserved},
+		RequestStatusObserved: {RequestStatusPending},
+		RequestStatusApproved: {RequestStatusPending},
+	},
+}
+
+// CanTransition indicates whether the track allows moving from one status to another
+func (t StatusTrack) CanTransition(from, to RequestStatus) bool {
+	for _, allowed := range statusTransitions[t][from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestStatuses holds the current status of every track of a request
+type RequestStatuses struct {
+	RequestID int64
+	Global    RequestStatus
+	Tasks     RequestStatus
+	Property  RequestStatus
+}
+
+// Of returns the status of the given track
+func (s RequestStatuses) Of(track StatusTrack) RequestStatus {
+	switch track {
+	case StatusTrackTasks:
+		return s.Tasks
+	case StatusTrackProperty:
+		return s.Property
+	default:
+		return s.Global
+	}
+}
+
+// Verified returns the global status after a verification: the request requires
+// changes while any of the tracks is observed
+func (s RequestStatuses) Verified() RequestStatus {
+	if s.Tasks == RequestStatusObserved || s.Property == RequestStatusObserved {
+		return RequestStatusRequiresChanges
+	}
+	return RequestStatusVerified
+}
+
+// CanBeValidated indicates whether both tracks were approved and the request is pending validation
+func (s RequestStatuses) CanBeValidated() bool {
+	return s.Global == RequestStatusPending && s.Tasks == RequestStatusApproved && s.Property == RequestStatusApproved
+}
+
+// StatusChange represents a transition of a track, persisted in workflow_history
+type StatusChange struct {
+	Track        StatusTrack
+	From         RequestStatus
+	To           RequestStatus
+	UserID       int64
+	Observations string
+}
+
+// Recorded indicates whether the change is kept in the workflow history. The changes that don't modify the
+// status are skipped, except the rejected validation, that keeps the request pending with its reason
+func (c StatusChange) Recorded() bool {
+	return c.From != c.To || c.IsValidationRejection()
+}
+
+// IsValidationRejection indicates whether the change is the rejection of the validation, the only global
+// change from pending to pending
+func (c StatusChange) IsValidationRejection() bool {
+	return c.Track == StatusTrackGlobal && c.From == RequestStatusPending && c.To == RequestStatusPending
+}
+
+// NewStatusChange validates the transition against the state machine
+func NewStatusChange(track StatusTrack, from, to RequestStatus, userID int64, observations string) (StatusChange, error) {
+	if !track.CanTransition(from, to) {
+		return StatusChange{}, fmt.Errorf("%w: %s track from %d to %d", ErrInvalidStatusTransition, track, from, to)
+	}
+
+	return StatusChange{
+		Track:        track,
+		From:         from,
+		To:           to,
+		UserID:       userID,
+		Observations: observations,
+	}, nil
+}
+
+// Transition validates that the track is currently in the expected status and that
+// the state machine allows moving it to the new one
+func (s RequestStatuses) Transition(track StatusTrack, from, to RequestStatus, userID int64, observations string) (StatusChange, error) {
+	if current := s.Of(track); current != from {
+		return StatusChange{}, fmt.Errorf("%w: %s track is %d, expected %d", ErrInvalidStatusTransition, track, current, from)
+	}
+
+	return NewStatusChange(track, from, to, userID, observations)
+}
@@ -0,0 +1,139 @@
+package domain
+
+import "time"
+
+type TimelineEventType string
+
+// Timeline event types
+const (
+	TimelineEventCreated            TimelineEventType = "created"
+	TimelineEventRecordAssigned     TimelineEventType = "record_assigned"
+	TimelineEventRecordFailed       TimelineEventType = "record_failed"
+	TimelineEventTrackApproved      TimelineEventType = "track_approved"
+	TimelineEventTrackObserved      TimelineEventType = "track_observed"
+	TimelineEventTrackReopened      TimelineEventType = "track_reopened"
+	TimelineEventVerified           TimelineEventType = "verified"
+	TimelineEventObserved           TimelineEventType = "observed"
+	TimelineEventVerificationSent   TimelineEventType = "verification_sent"
+	TimelineEventResubmitted        TimelineEventType = "resubmitted"
+	TimelineEventValidated          TimelineEventType = "validated"
+	TimelineEventValidationRejected TimelineEventType = "validation_rejected"
+	TimelineEventWithdrawn          TimelineEventType = "withdrawn"
+	TimelineEventStatusChanged      TimelineEventType = "status_changed"
+	TimelineEventDocumentAdded      TimelineEventType = "document_added"
+)
+
+// Verification and validation documents are generated by the staff
+var staffDocumentTypes = map[int]bool{16: true, 17: true, 18: true}
+
+// TimelineEvent represents an entry of the history of a request
+type TimelineEvent struct {
+	Date                time.Time
+	Type                TimelineEventType
+	Track               StatusTrack
+	PreviousStatus      RequestStatus
+	PreviousStatusLabel string
+	Status              RequestStatus
+	StatusLabel         string
+	ActorUserID         int64
+	ActorName           string
+	Observations        string
+	DocumentType        int
+	DocumentName        string
+}
+
+// RequestHistory is the ordered timeline of a request
+type RequestHistory struct {
+	RequestID  int64
+	UserID     int64
+	FileNumber string
+	Events     []TimelineEvent
+}
+
+// VisibleToCitizen indicates whether the citizen sees the global status changes of this type. The
+// changes of the verification tracks are internal to the staff
+func (t TimelineEventType) VisibleToCitizen() bool {
+	switch t {
+	case TimelineEventCreated,
+		TimelineEventRecordAssigned,
+		TimelineEventRecordFailed,
+		TimelineEventVerified,
+		TimelineEventObserved,
+		TimelineEventResubmitted,
+		TimelineEventValidated,
+		TimelineEventValidationRejected,
+		TimelineEventWithdrawn:
+		return true
+	}
+	return false
+}
+
+// TimelineEventTypeFor classifies a status change of the workflow history
+func TimelineEventTypeFor(track StatusTrack, from, to RequestStatus) TimelineEventType {
+	if track != StatusTrackGlobal {
+		switch {
+		case to == RequestStatusApproved:
+			return TimelineEventTrackApproved
+		case to == RequestStatusObserved:
+			return TimelineEventTrackObserved
+		case from == RequestStatusApproved:
+			return TimelineEventValidationRejected
+		case from == RequestStatusObserved:
+			return TimelineEventTrackReopened
+		}
+		return TimelineEventStatusChanged
+	}
+
+	switch {
+	case to == RequestStatusProcessing:
+		return TimelineEventCreated
+	case from == RequestStatusProcessing && to == RequestStatusPending:
+		return TimelineEventRecordAssigned
+	case to == RequestStatusFailed:
+		return TimelineEventRecordFailed
+	case to == RequestStatusVerified:
+		return TimelineEventVerified
+	case to == RequestStatusRequiresChanges:
+		return TimelineEventObserved
+	case from == RequestStatusVerified && to == RequestStatusPending:
+		return TimelineEventVerificationSent
+	case from == RequestStatusRequiresChanges && to == RequestStatusPending:
+		return TimelineEventResubmitted
+	case from == RequestStatusPending && to == RequestStatusPending:
+		return TimelineEventValidationRejected
+	case to == RequestStatusValidated:
+		return TimelineEventValidated
+	case to == RequestStatusWithdrawn:
+		return TimelineEventWithdrawn
+	}
+	return TimelineEventStatusChanged
+}
+
+// CitizenView returns the history as seen by the citizen that submitted the request: only the
+// global status changes and the documents they submitted, without the names of the staff involved
+func (h *RequestHistory) CitizenView() *RequestHistory {
+	view := &RequestHistory{
+		RequestID:  h.RequestID,
+		UserID:     h.UserID,
+		FileNumber: h.FileNumber,
+		Events:     make([]TimelineEvent, 0, len(h.Events)),
+	}
+
+	for _, event := range h.Events {
+		if event.Type == TimelineEventDocumentAdded {
+			if staffDocumentTypes[event.DocumentType] {
+				continue
+			}
+		} else if event.Track != StatusTrackGlobal || !event.Type.VisibleToCitizen() {
+			continue
+		}
+
+		if event.ActorUserID != h.UserID {
+			event.ActorUserID = 0
+			event.ActorName = ""
+		}
+		view.Events = append(view.Events, event)
+	}
+
+	return view
+}
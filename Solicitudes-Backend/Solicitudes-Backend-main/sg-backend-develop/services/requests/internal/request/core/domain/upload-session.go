@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TusVersion is the version of the tus resumable upload protocol supported by the service
+const TusVersion = "1.0.0"
+
+// TusExtensions are the extensions of the tus protocol supported by the service
+var TusExtensions = []string{"creation", "termination", "expiration"}
+
+const MaxUploadFilenameLength = 255
+
+var (
+	ErrUploadNotFound       = errors.New("upload not found")
+	ErrUploadOffsetMismatch = errors.New("upload offset does not match the stored offset")
+	ErrUploadTooLarge       = errors.New("upload exceeds the maximum size")
+	ErrInvalidUploadSession = errors.New("invalid upload")
+)
+
+// UploadSession is a file uploaded in chunks before it is attached to a request. The chunks are staged
+// in the upload store and Offset is the number of bytes received so far
+type UploadSession struct {
+	ID        string
+	UserID    int64
+	Type      DocumentTypeID
+	Filename  string
+	Length    int64
+	Offset    int64
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// NewUploadID generates the random identifier of an upload, used in its URL
+func NewUploadID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("error generating upload id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// ValidUploadID reports whether id has the format generated by NewUploadID. The ID comes in the URL
+// and names the staged file, so anything else is rejected
+func ValidUploadID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// Validate checks the upload declared by the client before any chunk is received. The request type is
+// not known yet, so allowed has the formats accepted by any of them
+func (s *UploadSession) Validate(options UploadOptions, allowed UploadTypes) error {
+	if _, ok := allowed[s.Type]; !ok {
+		return fmt.Errorf("%w: document type %d can't be uploaded", ErrInvalidUploadSession, s.Type)
+	}
+
+	s.Filename = strings.TrimSpace(s.Filename)
+	if len(s.Filename) > MaxUploadFilenameLength {
+		return fmt.Errorf("%w: the filename must have at most %d characters", ErrInvalidUploadSession, MaxUploadFilenameLength)
+	}
+
+	if s.Length <= 0 {
+		return fmt.Errorf("%w: the upload length must be greater than zero", ErrInvalidUploadSession)
+	}
+	if options.MaxFileSize > 0 && s.Length > options.MaxFileSize {
+		return fmt.Errorf("%w: %d bytes, the maximum is %s", ErrUploadTooLarge, s.Length, formatUploadSize(options.MaxFileSize))
+	}
+
+	return nil
+}
+
+// CheckChunk checks that a chunk continues the upload where the stored data ends and doesn't go past
+// the declared length
+func (s *UploadSession) CheckChunk(offset, size int64) error {
+	if offset != s.Offset {
+		return fmt.Errorf("%w: expected %d, got %d", ErrUploadOffsetMismatch, s.Offset, offset)
+	}
+	if offset+size > s.Length {
+		return fmt.Errorf("%w: the chunk goes past the upload length of %d bytes", ErrUploadTooLarge, s.Length)
+	}
+	return nil
+}
+
+// Completed reports whether every byte of the file was received
+func (s *UploadSession) Completed() bool {
+	return s.Offset == s.Length
+}
+
+// Expired reports whether the upload can no longer be resumed or used in a request
+func (s *UploadSession) Expired(now time.Time) bool {
+	return !s.ExpiresAt.After(now)
+}
+
+// ValidateContent validates the file once every chunk was received, with the same rules as the files
+// sent inline in the request. The format is checked again against the request type that uses the upload
+func (s *UploadSession) ValidateContent(options UploadOptions, allowed UploadTypes, data []byte) error {
+	if reason := options.checkData(allowed[s.Type], data); reason != "" {
+		return UploadErrors{{Index: 0, Type: s.Type, Reason: reason}}
+	}
+	return nil
+}
+
+// CheckUpload checks that the document can use the upload it refers to instead of inline content.
+// It returns the reason it can't, if any
+func (d *DocumentRequest) CheckUpload(session *UploadSession, now time.Time) string {
+	switch {
+	case d.Content != "":
+		return "content and upload_id can't be sent together"
+	case session == nil || session.Expired(now):
+		return fmt.Sprintf("upload %s not found", d.UploadID)
+	case !session.Completed():
+		return fmt.Sprintf("upload %s is not complete, %d of %d bytes received", session.ID, session.Offset, session.Length)
+	case session.Type != d.Type:
+		return fmt.Sprintf("upload %s was created for document type %d", session.ID, session.Type)
+	}
+	return ""
+}
+
+// AttachUpload sets the content of the document from the staged file. From here on the document follows
+// the same path as the files sent inline
+func (d *DocumentRequest) AttachUpload(session *UploadSession, data []byte) {
+	d.Content = base64.StdEncoding.EncodeToString(data)
+	if d.Name == "" {
+		d.Name = session.Filename
+	}
+}
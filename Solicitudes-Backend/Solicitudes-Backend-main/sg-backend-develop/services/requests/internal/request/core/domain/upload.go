@@ -0,0 +1,319 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MIME types of the files the citizen can upload
+const (
+	MimeTypePDF  = "application/pdf"
+	MimeTypeJPEG = "image/jpeg"
+	MimeTypePNG  = "image/png"
+)
+
+// RequestUploadIndex is the index of the upload errors that apply to all the files of the request
+const RequestUploadIndex = -1
+
+var ErrInvalidUpload = errors.New("invalid upload")
+
+// DefaultUploadTypes are the MIME types accepted for a document when its definition doesn't list them
+var DefaultUploadTypes = []string{MimeTypePDF, MimeTypeJPEG, MimeTypePNG}
+
+// UploadTypes lists the MIME types accepted for each document type uploaded by the citizen
+type UploadTypes map[DocumentTypeID][]string
+
+// AllowedUploadTypes is the default for the document types that no request type declares, such as the
+// message attachments. The documents generated by the service are never uploaded and are not listed
+var AllowedUploadTypes = UploadTypes{
+	9:                             {MimeTypePDF, MimeTypeJPEG, MimeTypePNG},
+	10:                            {MimeTypePDF, MimeTypeJPEG, MimeTypePNG},
+	11:                            {MimeTypePDF, MimeTypeJPEG, MimeTypePNG},
+	12:                            {MimeTypePDF, MimeTypeJPEG, MimeTypePNG},
+	14:                            {MimeTypePDF, MimeTypeJPEG, MimeTypePNG},
+	DocumentTypeMessageAttachment: {MimeTypePDF, MimeTypeJPEG, MimeTypePNG},
+}
+
+// uploadSignatures are the magic bytes of each accepted MIME type
+var uploadSignatures = []struct {
+	mimeType string
+	magic    []byte
+}{
+	{MimeTypePDF, []byte("%PDF-")},
+	{MimeTypeJPEG, []byte{0xFF, 0xD8, 0xFF}},
+	{MimeTypePNG, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}},
+}
+
+// UploadOptions limits the size of the uploaded files, after decoding them, and configures how long
+// the resumable uploads are kept until a request uses them
+type UploadOptions struct {
+	MaxFileSize     int64
+	MaxRequestSize  int64
+	TTL             time.Duration
+	CleanupInterval time.Duration
+}
+
+// Upload is a file sent by the citizen as base64 content
+type Upload struct {
+	Type    DocumentTypeID
+	Name    string
+	Content string
+}
+
+// UploadError is the problem found in one of the uploaded files. Index is the position of the file in
+// the payload, or RequestUploadIndex when the problem is about all the files together
+type UploadError struct {
+	Index  int
+	Type   DocumentTypeID
+	Reason string
+}
+
+// UploadErrors collects the problems of every uploaded file so the client can fix them at once
+type UploadErrors []UploadError
+
+func (e UploadErrors) Error() string {
+	reasons := make([]string, len(e))
+	for i, uploadErr := range e {
+		if uploadErr.Index == RequestUploadIndex {
+			reasons[i] = uploadErr.Reason
+			continue
+		}
+		reasons[i] = fmt.Sprintf("file %d (type %d): %s", uploadErr.Index, uploadErr.Type, uploadErr.Reason)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidUpload, strings.Join(reasons, "; "))
+}
+
+func (e UploadErrors) Unwrap() error {
+	return ErrInvalidUpload
+}
+
+// DocumentUploads returns the files of a request. The position of each file is kept
+func DocumentUploads(documents []DocumentRequest) []Upload {
+	uploads := make([]Upload, len(documents))
+	for i, doc := range documents {
+		uploads[i] = Upload{Type: doc.Type, Name: doc.Name, Content: doc.Content}
+	}
+	return uploads
+}
+
+// MergeUploadTypes returns the MIME types accepted for each document type by any of the request types.
+// It is used before the request type is known, and the files are checked again when it is
+func MergeUploadTypes(requestTypes []RequestType) UploadTypes {
+	merged := AllowedUploadTypes.clone()
+	for i := range requestTypes {
+		for docType, mimeTypes := range requestTypes[i].UploadTypes() {
+			for _, mimeType := range mimeTypes {
+				if !containsMimeType(merged[docType], mimeType) {
+					merged[docType] = append(merged[docType], mimeType)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+func (t UploadTypes) clone() UploadTypes {
+	cloned := make(UploadTypes, len(t))
+	for docType, mimeTypes := range t {
+		cloned[docType] = append([]string(nil), mimeTypes...)
+	}
+	return cloned
+}
+
+// Validate checks the files before they are stored: the content must be valid base64, the format must
+// be accepted for the document type according to its magic bytes, the sizes must be within the limits
+// and a PDF must be well formed and not encrypted. Files without content are not uploads and are skipped
+func (o UploadOptions) Validate(allowed UploadTypes, uploads []Upload) error {
+	var errs UploadErrors
+	var total int64
+
+	for i, upload := range uploads {
+		if upload.Content == "" {
+			continue
+		}
+
+		size, reason := o.checkUpload(allowed, upload)
+		total += size
+		if reason != "" {
+			errs = append(errs, UploadError{Index: i, Type: upload.Type, Reason: reason})
+		}
+	}
+
+	if o.MaxRequestSize > 0 && total > o.MaxRequestSize {
+		errs = append(errs, UploadError{
+			Index:  RequestUploadIndex,
+			Reason: fmt.Sprintf("the files exceed the maximum size of %s per request", formatUploadSize(o.MaxRequestSize)),
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkUpload validates one file and returns its decoded size and the reason it was rejected, if any
+func (o UploadOptions) checkUpload(allowed UploadTypes, upload Upload) (int64, string) {
+	mimeTypes, ok := allowed[upload.Type]
+	if !ok {
+		return 0, fmt.Sprintf("document type %d can't be uploaded", upload.Type)
+	}
+
+	// The size is estimated before decoding so an oversized file is not decoded. The estimate can
+	// exceed the real size by the two padding bytes
+	if estimated := int64(base64.StdEncoding.DecodedLen(len(upload.Content))); o.MaxFileSize > 0 && estimated > o.MaxFileSize+2 {
+		return estimated, fmt.Sprintf("the file exceeds the maximum size of %s", formatUploadSize(o.MaxFileSize))
+	}
+
+	data, err := base64.StdEncoding.DecodeString(upload.Content)
+	if err != nil {
+		return 0, "the content is not valid base64"
+	}
+
+	return int64(len(data)), o.checkData(mimeTypes, data)
+}
+
+// checkData validates the decoded content of a file and returns the reason it was rejected, if any
+func (o UploadOptions) checkData(allowed []string, data []byte) string {
+	size := int64(len(data))
+	if size == 0 {
+		return "the file is empty"
+	}
+	if o.MaxFileSize > 0 && size > o.MaxFileSize {
+		return fmt.Sprintf("the file exceeds the maximum size of %s", formatUploadSize(o.MaxFileSize))
+	}
+
+	mimeType := DetectUploadType(data)
+	if mimeType == "" {
+		return fmt.Sprintf("unsupported file format, allowed: %s", strings.Join(allowed, ", "))
+	}
+	if !containsMimeType(allowed, mimeType) {
+		return fmt.Sprintf("%s is not allowed for this document type, allowed: %s", mimeType, strings.Join(allowed, ", "))
+	}
+
+	if mimeType == MimeTypePDF {
+		if reason := checkPDF(data); reason != "" {
+			return reason
+		}
+	}
+
+	return ""
+}
+
+// DetectUploadType returns the MIME type of the file according to its magic bytes, or an empty string
+// when it is not one of the accepted formats
+func DetectUploadType(data []byte) string {
+	for _, signature := range uploadSignatures {
+		if bytes.HasPrefix(data, signature.magic) {
+			return signature.mimeType
+		}
+	}
+	return ""
+}
+
+// pdfTailSize is how far from the end of a PDF the startxref and %%EOF markers are searched
+const pdfTailSize = 1024
+
+// checkPDF checks the structure of a PDF: it has to end with %%EOF, the startxref offset has to point
+// to the cross-reference table or stream, and the trailer can't reference an encryption dictionary.
+// An incremental update repeats the entries of the previous trailer, so only the last one is checked
+func checkPDF(data []byte) string {
+	tail := data[max(0, len(data)-pdfTailSize):]
+	if !bytes.Contains(tail, []byte("%%EOF")) {
+		return "the PDF is truncated or corrupted: missing %%EOF marker"
+	}
+
+	idx := bytes.LastIndex(tail, []byte("startxref"))
+	if idx < 0 {
+		return "the PDF is corrupted: missing startxref"
+	}
+
+	fields := bytes.Fields(tail[idx+len("startxref"):])
+	if len(fields) == 0 {
+		return "the PDF is corrupted: missing cross-reference offset"
+	}
+	offset, err := strconv.Atoi(string(fields[0]))
+	if err != nil || offset <= 0 || offset >= len(data) {
+		return "the PDF is corrupted: invalid cross-reference offset"
+	}
+
+	section := data[offset:]
+	end := bytes.Index(section, []byte("startxref"))
+	if end < 0 {
+		end = len(section)
+	}
+
+	switch trimmed := bytes.TrimLeft(section, " \t\r\n\f\x00"); {
+	case bytes.HasPrefix(trimmed, []byte("xref")):
+		// Cross-reference table: the trailer dictionary follows the table
+	case isPDFObjectHeader(trimmed):
+		// Cross-reference stream: the trailer entries are in the dictionary of the stream
+		if i := bytes.Index(section[:end], []byte("stream")); i >= 0 {
+			end = i
+		}
+	default:
+		return "the PDF is corrupted: the cross-reference offset is invalid"
+	}
+
+	if hasPDFName(section[:end], []byte("/Encrypt")) {
+		return "the PDF is encrypted or password protected"
+	}
+
+	return ""
+}
+
+// isPDFObjectHeader reports whether data starts with an indirect object header such as "12 0 obj"
+func isPDFObjectHeader(data []byte) bool {
+	fields := bytes.Fields(data[:min(len(data), 64)])
+	if len(fields) < 3 {
+		return false
+	}
+	if _, err := strconv.Atoi(string(fields[0])); err != nil {
+		return false
+	}
+	if _, err := strconv.Atoi(string(fields[1])); err != nil {
+		return false
+	}
+	return bytes.HasPrefix(fields[2], []byte("obj"))
+}
+
+// hasPDFName reports whether the name appears as a whole token, so /Encrypt doesn't match /EncryptMetadata
+func hasPDFName(data, name []byte) bool {
+	for {
+		i := bytes.Index(data, name)
+		if i < 0 {
+			return false
+		}
+		next := i + len(name)
+		if next == len(data) || !isPDFRegularChar(data[next]) {
+			return true
+		}
+		data = data[next:]
+	}
+}
+
+func isPDFRegularChar(c byte) bool {
+	return c > ' ' && !strings.ContainsRune("()<>[]{}/%", rune(c))
+}
+
+func containsMimeType(allowed []string, mimeType string) bool {
+	for _, t := range allowed {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+func formatUploadSize(size int64) string {
+	const mb = 1 << 20
+	if size%mb == 0 {
+		return fmt.Sprintf("%d MB", size/mb)
+	}
+	return fmt.Sprintf("%d bytes", size)
+}
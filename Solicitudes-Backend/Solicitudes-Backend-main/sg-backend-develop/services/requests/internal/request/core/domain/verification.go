@@ -14,6 +14,11 @@ type Verification struct {
 	RequesterCuil     string
 	RequesterAddress  string
 	Documents         []Document
+	Activities        []string
+	RequestTypeID     int
+	Statuses          RequestStatuses
+	Milestones        SLAMilestones
+	SLA               *SLA // nil when the request is not subject to an SLA
 }
 
 type Document struct {
@@ -24,4 +29,6 @@ type Document struct {
 	Content      string
 	VerifiedBy   string
 	VerifiedDate string
+	Verified     bool   // accepted by the verifier
+	Observations string // reason of the rejection
 }
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+const (
+	MaxWebhookNameLength        = 100
+	MinWebhookSecretLength      = 16
+	MaxWebhookSecretLength      = 100
+	DefaultWebhookDeliveryLimit = 50
+	MaxWebhookDeliveryLimit     = 200
+)
+
+var (
+	ErrInvalidWebhook          = errors.New("invalid webhook subscription")
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// WebhookEventTypes are the lifecycle events of a request that can be subscribed to. The created event
+// is sent when the file number is assigned, so it also stands for the record assignment
+var WebhookEventTypes = []TimelineEventType{
+	TimelineEventCreated,
+	TimelineEventRecordFailed,
+	TimelineEventVerified,
+	TimelineEventObserved,
+	TimelineEventResubmitted,
+	TimelineEventValidated,
+	TimelineEventWithdrawn,
+}
+
+// IsWebhookEvent indicates whether the event type is delivered to the subscriptions
+func IsWebhookEvent(t TimelineEventType) bool {
+	for _, eventType := range WebhookEventTypes {
+		if eventType == t {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryProcessing WebhookDeliveryStatus = "processing"
+	WebhookDeliveryDone       WebhookDeliveryStatus = "done"
+	WebhookDeliveryDead       WebhookDeliveryStatus = "dead"
+)
+
+// WebhookSubscription is another system notified of the lifecycle events of the requests. Without
+// event types it receives all of them
+type WebhookSubscription struct {
+	ID         int64
+	Name       string
+	URL        string
+	Secret     string // signs the deliveries, only shown when the subscription is created
+	EventTypes []TimelineEventType
+	Active     bool
+	CreatedBy  int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Validate checks the subscription and removes the repeated event types
+func (s *WebhookSubscription) Validate() error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" || len(s.Name) > MaxWebhookNameLength {
+		return fmt.Errorf("%w: name is required and must have at most %d characters", ErrInvalidWebhook, MaxWebhookNameLength)
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https url", ErrInvalidWebhook)
+	}
+
+	if len(s.Secret) < MinWebhookSecretLength || len(s.Secret) > MaxWebhookSecretLength {
+		return fmt.Errorf("%w: secret must have between %d and %d characters", ErrInvalidWebhook, MinWebhookSecretLength, MaxWebhookSecretLength)
+	}
+
+	seen := make(map[TimelineEventType]bool, len(s.EventTypes))
+	eventTypes := make([]TimelineEventType, 0, len(s.EventTypes))
+	for _, eventType := range s.EventTypes {
+		if !IsWebhookEvent(eventType) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, eventType)
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	s.EventTypes = eventTypes
+
+	return nil
+}
+
+// NewWebhookSecret generates a random secret for a subscription created without one
+func NewWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("error generating webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
+
+// WebhookDelivery is the delivery of an event to a subscription. The deliveries are kept as the
+// log of what was sent; a redelivery is a new delivery with the payload of the original one
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	RequestID      int64
+	EventType      TimelineEventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	LastStatusCode int
+	LastError      string
+	NextAttemptAt  time.Time
+	DeliveredAt    *time.Time
+	RedeliveryOf   int64
+	CreatedAt      time.Time
+	URL            string // of the subscription, loaded to deliver it
+	Secret         string
+}
+
+// WebhookDeliveryQuery lists the latest deliveries of a subscription, optionally by status
+type WebhookDeliveryQuery struct {
+	SubscriptionID int64
+	Status         WebhookDeliveryStatus
+	Limit          int
+}
+
+// Normalize applies the default limit and checks the status
+func (q *WebhookDeliveryQuery) Normalize() error {
+	switch q.Status {
+	case "", WebhookDeliveryPending, WebhookDeliveryProcessing, WebhookDeliveryDone, WebhookDeliveryDead:
+	default:
+		return fmt.Errorf("%w: unknown delivery status %q", ErrInvalidWebhook, q.Status)
+	}
+
+	if q.Limit <= 0 {
+		q.Limit = DefaultWebhookDeliveryLimit
+	}
+	q.Limit = min(q.Limit, MaxWebhookDeliveryLimit)
+
+	return nil
+}
+
+// SignWebhook signs the timestamp and the body with HMAC-SHA256, as "t=<unix seconds>,v1=<hex>". The
+// subscriber recomputes the signature over "<t>.<body>" and should reject old timestamps
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookOptions configures the webhook worker
+type WebhookOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Lease        time.Duration
+	Timeout      time.Duration
+}
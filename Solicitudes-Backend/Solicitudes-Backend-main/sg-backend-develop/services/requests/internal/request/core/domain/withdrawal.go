@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Document type of the IF added to the record when the citizen withdraws the request
+const DocumentTypeWithdrawal DocumentTypeID = 20
+
+// Maximum length of the withdrawal reason
+const MaxWithdrawalReasonLength = 2000
+
+var (
+	ErrWithdrawalReasonRequired = errors.New("withdrawal reason is required")
+	ErrWithdrawalReasonTooLong  = errors.New("withdrawal reason is too long")
+)
+
+// Withdrawal is the decision of the citizen to cancel a request they submitted
+type Withdrawal struct {
+	FileNumber  string
+	RequestType string
+	UserID      int64
+	Cuil        string
+	FullName    string
+	Reason      string
+	Date        time.Time
+}
+
+// Validate checks that the citizen explained why the request is withdrawn
+func (w *Withdrawal) Validate() error {
+	w.Reason = strings.TrimSpace(w.Reason)
+	if w.Reason == "" {
+		return ErrWithdrawalReasonRequired
+	}
+	if len([]rune(w.Reason)) > MaxWithdrawalReasonLength {
+		return fmt.Errorf("%w: at most %d characters", ErrWithdrawalReasonTooLong, MaxWithdrawalReasonLength)
+	}
+	return nil
+}
+
+// DocumentText returns the text of the IF document that records the withdrawal in the GDE record
+func (w *Withdrawal) DocumentText() string {
+	return fmt.Sprintf(`Desistimiento de la solicitud de %s
+En el día de la fecha, %s, el/la Sr./Sra. %s, CUIL %s, desiste de la solicitud tramitada en el expediente %s a través de la plataforma de Solicitudes de San Isidro.
+Motivo informado por el/la solicitante: %s
+Se deja constancia de que la solicitud queda dada de baja y no continuará su tramitación.`,
+		w.RequestType,
+		w.Date.Format("02/01/2006"),
+		w.FullName,
+		w.Cuil,
+		w.FileNumber,
+		w.Reason,
+	)
+}
@@ -0,0 +1,51 @@
+package request
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+type draftCleaner struct {
+	repository ports.Repository
+	options    domain.DraftOptions
+}
+
+func NewDraftCleaner(repository ports.Repository, options domain.DraftOptions) ports.DraftCleaner {
+	return &draftCleaner{
+		repository: repository,
+		options:    options,
+	}
+}
+
+// Start elimina periódicamente los borradores que no se modificaron durante el TTL configurado,
+// hasta que se cancele el contexto
+func (c *draftCleaner) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.options.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		c.clean(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *draftCleaner) clean(ctx context.Context) {
+	deleted, err := c.repository.DeleteStaleDrafts(ctx, time.Now().Add(-c.options.TTL))
+	if err != nil {
+		log.Printf("drafts: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("drafts: %d stale drafts deleted", deleted)
+	}
+}
@@ -1,6 +1,9 @@
 package request
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -12,7 +15,6 @@ import (
 func parseInput(inputText string) domain.Suggestion {
 	var addrNameParts []string
 	var addrNum, ablNum int64
-	var nameStr string
 
 	tokens := strings.Fields(inputText)
 
@@ -24,15 +26,12 @@ func parseInput(inputText string) domain.Suggestion {
 			} else if len(token) <= 4 {
 				addrNum = num
 			}
-		} else {
-			nameStr += sdktools.NormalizeString(token)
+		} else if part := sdktools.NormalizeString(token); part != "" {
+			// Las palabras se mantienen separadas, igual que en la columna properties.street_search
+			addrNameParts = append(addrNameParts, part)
 		}
 	}
 
-	if nameStr != "" {
-		addrNameParts = append(addrNameParts, nameStr)
-	}
-
 	AddrStreet := strings.Join(addrNameParts, " ")
 
 	return domain.Suggestion{
@@ -41,3 +40,65 @@ func parseInput(inputText string) domain.Suggestion {
 		AblNum:     ablNum,
 	}
 }
+
+func newOutboxMessage(action domain.OutboxAction, payload any) (domain.OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return domain.OutboxMessage{}, fmt.Errorf("error marshaling %s payload: %w", action, err)
+	}
+
+	return domain.OutboxMessage{
+		Action:  action,
+		Payload: data,
+	}, nil
+}
+
+// validateDraftDocuments valida los archivos que se guardan en el borrador. Como el tipo de trámite puede
+// no estar elegido se aceptan los formatos de cualquier tipo habilitado; el formato y el límite por
+// solicitud se vuelven a controlar al presentarlo, con el tipo de trámite y todos los archivos del borrador
+func (u *useCases) validateDraftDocuments(ctx context.Context, documents []domain.DraftDocument) error {
+	files := make([]domain.Upload, len(documents))
+	for i, doc := range documents {
+		if strings.TrimSpace(doc.Content) == "" {
+			return fmt.Errorf("%w: %d", domain.ErrEmptyDraftDocument, doc.Type)
+		}
+		files[i] = domain.Upload{Type: doc.Type, Name: doc.Name, Content: doc.Content}
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	allowed, err := u.uploadTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	return u.uploads.Validate(allowed, files)
+}
+
+// uploadTypes devuelve los formatos aceptados para cada tipo de documento por los tipos de trámite habilitados
+func (u *useCases) uploadTypes(ctx context.Context) (domain.UploadTypes, error) {
+	requestTypes, err := u.repository.GetRequestTypes(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting request types: %w", err)
+	}
+
+	return domain.MergeUploadTypes(requestTypes), nil
+}
+
+// applyBulk aplica la acción a cada expediente. Si se cancela el contexto las solicitudes restantes
+// quedan sin procesar, con el error del contexto
+func applyBulk(ctx context.Context, fileNumbers []string, apply func(string) error) []domain.BulkResult {
+	results := make([]domain.BulkResult, len(fileNumbers))
+	for i, fileNumber := range fileNumbers {
+		results[i].FileNumber = fileNumber
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Err = apply(fileNumber)
+	}
+
+	return results
+}
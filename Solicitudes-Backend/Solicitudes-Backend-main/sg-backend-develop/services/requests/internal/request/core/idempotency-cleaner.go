@@ -0,0 +1,50 @@
+package request
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+type idempotencyCleaner struct {
+	repository ports.Repository
+	options    domain.IdempotencyOptions
+}
+
+func NewIdempotencyCleaner(repository ports.Repository, options domain.IdempotencyOptions) ports.IdempotencyCleaner {
+	return &idempotencyCleaner{
+		repository: repository,
+		options:    options,
+	}
+}
+
+// Start elimina periódicamente las claves de idempotencia vencidas, hasta que se cancele el contexto
+func (c *idempotencyCleaner) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.options.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		c.clean(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *idempotencyCleaner) clean(ctx context.Context) {
+	deleted, err := c.repository.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		log.Printf("idempotency: %v", err)
+		return
+	}
+
+	if deleted > 0 {
+		log.Printf("idempotency: %d expired keys deleted", deleted)
+	}
+}
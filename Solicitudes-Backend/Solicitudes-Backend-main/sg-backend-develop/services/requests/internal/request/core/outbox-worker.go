@@ -0,0 +1,352 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+type outboxWorker struct {
+	repository ports.Repository
+	httpClient ports.HttpClient
+	options    domain.OutboxOptions
+}
+
+func NewOutboxWorker(repository ports.Repository, httpClient ports.HttpClient, options domain.OutboxOptions) ports.OutboxWorker {
+	return &outboxWorker{
+		repository: repository,
+		httpClient: httpClient,
+		options:    options,
+	}
+}
+
+// Start procesa el outbox hasta que se cancele el contexto
+func (w *outboxWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.processBatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *outboxWorker) processBatch(ctx context.Context) {
+	msgs, err := w.repository.ClaimOutboxMessages(ctx, w.options.BatchSize, w.options.Lease)
+	if err != nil {
+		log.Printf("outbox: %v", err)
+		return
+	}
+
+	for i, msg := range msgs {
+		if i > 0 && !w.throttle(ctx) {
+			return
+		}
+		w.process(ctx, msg)
+	}
+}
+
+// throttle espacia las entregas según el límite configurado, para no saturar al file-manager y al mailer
+// cuando se encolan muchos mensajes juntos, por ejemplo en las acciones masivas. Los mensajes tomados que
+// quedan sin entregar al cancelar el contexto se reintentan cuando vence el lease
+func (w *outboxWorker) throttle(ctx context.Context) bool {
+	if w.options.RateLimit <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(time.Second / time.Duration(w.options.RateLimit))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (w *outboxWorker) process(ctx context.Context, msg domain.OutboxMessage) {
+	followUps, err := w.deliver(ctx, msg)
+	if err == nil {
+		if err := w.repository.CompleteOutboxMessage(ctx, msg.ID, followUps...); err != nil {
+			log.Printf("outbox: %v", err)
+		}
+		return
+	}
+
+	dead := msg.Attempts >= w.options.MaxAttempts
+	log.Printf("outbox: message %d (%s) attempt %d failed: %v", msg.ID, msg.Action, msg.Attempts, err)
+
+	if err := w.repository.FailOutboxMessage(ctx, msg.ID, err.Error(), w.backoff(msg.Attempts), dead); err != nil {
+		log.Printf("outbox: %v", err)
+		return
+	}
+
+	if dead {
+		w.onDeadLetter(ctx, msg)
+	}
+}
+
+func (w *outboxWorker) deliver(ctx context.Context, msg domain.OutboxMessage) (followUps []domain.OutboxMessage, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic delivering outbox message: %v", r)
+		}
+	}()
+
+	switch msg.Action {
+	case domain.OutboxActionCreateRecord:
+		return w.createRecord(ctx, msg)
+	case domain.OutboxActionUpdateRecordDocuments:
+		return w.updateRecordDocuments(ctx, msg)
+	case domain.OutboxActionSendVerificationDocument:
+		return w.sendVerificationDocument(ctx, msg)
+	case domain.OutboxActionSendValidationDocument:
+		return w.sendValidationDocument(ctx, msg)
+	case domain.OutboxActionSendWithdrawalDocument:
+		return nil, w.sendWithdrawalDocument(ctx, msg)
+	case domain.OutboxActionSendCreatedEmail,
+		domain.OutboxActionSendObservationsEmail,
+		domain.OutboxActionSendValidatedEmail,
+		domain.OutboxActionSendResubmittedEmail,
+		domain.OutboxActionSendMessageEmail,
+		domain.OutboxActionSendWithdrawnEmail:
+		return nil, w.sendEmail(ctx, msg)
+	default:
+		return nil, fmt.Errorf("unknown outbox action: %s", msg.Action)
+	}
+}
+
+// onDeadLetter refleja en la solicitud que no se pudo completar el trámite en GDE
+func (w *outboxWorker) onDeadLetter(ctx context.Context, msg domain.OutboxMessage) {
+	var err error
+	switch msg.Action {
+	case domain.OutboxActionCreateRecord:
+		err = w.repository.TransitionRequestStatus(ctx, msg.RequestID, domain.RequestStatusProcessing, domain.RequestStatusFailed, "")
+	case domain.OutboxActionUpdateRecordDocuments:
+		err = w.repository.TransitionRequestStatus(ctx, msg.RequestID, domain.RequestStatusPending, domain.RequestStatusRequiresChanges, "")
+	}
+
+	if err != nil {
+		log.Printf("outbox: %v", err)
+	}
+}
+
+func (w *outboxWorker) backoff(attempts int) time.Duration {
+	delay := w.options.BaseBackoff
+	for i := 1; i < attempts && delay < w.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > w.options.MaxBackoff {
+		return w.options.MaxBackoff
+	}
+	return delay
+}
+
+func (w *outboxWorker) createRecord(ctx context.Context, msg domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	var req domain.Request
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return nil, fmt.Errorf("error decoding payload: %w", err)
+	}
+	req.ID = msg.RequestID
+
+	// Si un intento anterior ya obtuvo el expediente no se vuelve a crear
+	current, err := w.repository.GetRequestByID(ctx, msg.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	code := current.FileNumber
+	if code == "" {
+		// Los mensajes anteriores a request_files traen los archivos en el payload
+		if len(req.Documents) == 0 {
+			req.Documents, err = w.repository.GetRequestFiles(ctx, req.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		code, err = w.httpClient.SendCreatedRequest(ctx, &req)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := w.repository.UpdateRequest(ctx, req.ID, code); err != nil {
+			return nil, err
+		}
+	}
+
+	followUp, err := newOutboxMessage(domain.OutboxActionSendCreatedEmail, domain.EmailPayload{
+		FileNumber: code,
+		Email:      req.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []domain.OutboxMessage{followUp}, nil
+}
+
+func (w *outboxWorker) updateRecordDocuments(ctx context.Context, msg domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	var req domain.Request
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return nil, fmt.Errorf("error decoding payload: %w", err)
+	}
+	req.ID = msg.RequestID
+
+	current, err := w.repository.GetRequestByExpCode(ctx, req.FileNumber)
+	if err != nil {
+		return nil, err
+	}
+	req.Observations = current.Observations
+	req.ObservationsTasks = current.ObservationsTasks
+
+	// Los mensajes anteriores a request_files traen los archivos en el payload
+	if len(req.Documents) == 0 {
+		req.Documents, err = w.repository.GetRequestFiles(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.httpClient.SendUpdateRequest(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	if err := w.repository.UpdateVerificationStatus(ctx, req.FileNumber); err != nil {
+		return nil, err
+	}
+
+	followUp, err := newOutboxMessage(domain.OutboxActionSendResubmittedEmail, domain.EmailPayload{
+		FileNumber: req.FileNumber,
+		Email:      req.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []domain.OutboxMessage{followUp}, nil
+}
+
+func (w *outboxWorker) sendVerificationDocument(ctx context.Context, msg domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	var payload domain.VerificationDocumentPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("error decoding payload: %w", err)
+	}
+
+	err := w.httpClient.SendVerificationDocument(ctx, payload.Content, payload.FileNumber, payload.Reference, payload.VerificationType, payload.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !payload.HasObservations {
+		// Si el otro circuito quedó observado la solicitud sigue requiriendo cambios
+		err := w.repository.TransitionRequestStatus(ctx, msg.RequestID, domain.RequestStatusVerified, domain.RequestStatusPending, "")
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			log.Printf("outbox: request %d: %v", msg.RequestID, err)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	req, email, err := w.requestAndEmail(ctx, payload.FileNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	followUp, err := newOutboxMessage(domain.OutboxActionSendObservationsEmail, domain.EmailPayload{
+		FileNumber:   payload.FileNumber,
+		Email:        email,
+		Observations: strings.TrimSpace(req.Observations) + "</br>" + strings.TrimSpace(req.ObservationsTasks),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []domain.OutboxMessage{followUp}, nil
+}
+
+func (w *outboxWorker) sendValidationDocument(ctx context.Context, msg domain.OutboxMessage) ([]domain.OutboxMessage, error) {
+	var payload domain.ValidationDocumentPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("error decoding payload: %w", err)
+	}
+
+	if err := w.httpClient.SendValidationDocument(ctx, payload.FileNumber, payload.Content, payload.Username); err != nil {
+		return nil, err
+	}
+
+	_, email, err := w.requestAndEmail(ctx, payload.FileNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	followUp, err := newOutboxMessage(domain.OutboxActionSendValidatedEmail, domain.EmailPayload{
+		FileNumber: payload.FileNumber,
+		Email:      email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []domain.OutboxMessage{followUp}, nil
+}
+
+func (w *outboxWorker) sendWithdrawalDocument(ctx context.Context, msg domain.OutboxMessage) error {
+	var payload domain.WithdrawalDocumentPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("error decoding payload: %w", err)
+	}
+
+	return w.httpClient.SendWithdrawalDocument(ctx, payload.FileNumber, payload.Content, payload.Username)
+}
+
+func (w *outboxWorker) sendEmail(ctx context.Context, msg domain.OutboxMessage) error {
+	var payload domain.EmailPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return fmt.Errorf("error decoding payload: %w", err)
+	}
+
+	switch msg.Action {
+	case domain.OutboxActionSendCreatedEmail:
+		return w.httpClient.SendEmail(ctx, payload.FileNumber, payload.Email)
+	case domain.OutboxActionSendObservationsEmail:
+		return w.httpClient.SendEmailUpdate(ctx, payload.FileNumber, payload.Email, payload.Observations)
+	case domain.OutboxActionSendValidatedEmail:
+		return w.httpClient.SendEmailValidateRequest(ctx, payload.FileNumber, payload.Email)
+	case domain.OutboxActionSendMessageEmail:
+		return w.httpClient.SendEmailNewMessage(ctx, payload.FileNumber, payload.Email, payload.Observations, payload.RequestType)
+	case domain.OutboxActionSendWithdrawnEmail:
+		return w.httpClient.SendEmailWithdrawn(ctx, payload.FileNumber, payload.Email, payload.Observations, payload.RequestType)
+	default:
+		return w.httpClient.SendEmailUpdateRequest(ctx, payload.FileNumber, payload.Email)
+	}
+}
+
+// requestAndEmail obtiene la solicitud y el email del ciudadano que la presentó
+func (w *outboxWorker) requestAndEmail(ctx context.Context, fileNumber string) (*domain.Request, string, error) {
+	req, err := w.repository.GetRequestByExpCode(ctx, fileNumber)
+	if err != nil {
+		return nil, "", err
+	}
+
+	email, err := w.repository.GetPersonByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return req, email, nil
+}
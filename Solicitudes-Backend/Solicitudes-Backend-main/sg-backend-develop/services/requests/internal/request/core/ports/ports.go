@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
 )
@@ -11,48 +12,158 @@ type UseCases interface {
 	CreateRequestByUserID(context.Context, *domain.Request) error
 	GetAllRequestsByUserID(context.Context, int64) ([]domain.Request, error)
 	GetAllRequestsByCuil(context.Context, string) ([]domain.Request, error)
-	CheckAblOwnership(context.Context, string, int) (bool, error)
+	CheckAblOwnership(context.Context, string, int) (*domain.OwnershipCheck, error)
 	CreateRequestByCuil(context.Context, *domain.Request) error
 	UpdateRequest(context.Context, *domain.VerifiedRequest) error
 	UpdateRequestByFileNumber(context.Context, *domain.Request) error
 	ValidateRequest(context.Context, *domain.ValidateRequest) error
 	RequestsVerifications(context.Context, string) (*domain.Verification, error)
-	AllRequestsVerifications(context.Context) ([]domain.Verification, error)
-	AllRequestsValidations(context.Context) ([]domain.Verification, error)
+	AllRequestsVerifications(context.Context, domain.QueueQuery) (*domain.VerificationPage, error)
+	AllRequestsValidations(context.Context, domain.QueueQuery) (*domain.VerificationPage, error)
 	DocumentsByCode(context.Context, string) (*domain.Request, []domain.Document, string, error)
 	ValidationDocumentsByCode(context.Context, string) (*domain.Request, []domain.Document, []domain.Document, error)
 	DocumentByID(context.Context, string) (domain.Document, error)
 	GetRequestByID(context.Context, int64) (*domain.Request, error)
 	GetRequestByExpCode(context.Context, string) (*domain.Request, error)
+	GetRejectedDocuments(context.Context, string, string) ([]domain.Document, error)
+	GetDocumentVersions(ctx context.Context, fileNumber string, docType domain.DocumentTypeID) ([]domain.DocumentVersion, error)
+	GetDocumentVersion(ctx context.Context, fileNumber string, docType domain.DocumentTypeID, version int) (*domain.DocumentVersion, error)
+	GetRequestHistory(context.Context, string, string) (*domain.RequestHistory, error)
+	GetUserAccess(context.Context, string) (*domain.UserAccess, error)
+	PostMessage(context.Context, string, string, *domain.Message) error
+	GetMessageThread(context.Context, string, string) (*domain.MessageThread, error)
+	MarkMessagesRead(context.Context, string, string) error
+	GetRequestTypes(context.Context) ([]domain.RequestType, error)
+	CreateDraft(context.Context, string, *domain.Draft) error
+	GetDrafts(context.Context, string) ([]domain.Draft, error)
+	GetDraft(context.Context, int64, string) (*domain.Draft, error)
+	UpdateDraft(context.Context, string, *domain.Draft) error
+	DeleteDraftDocument(context.Context, int64, string, domain.DocumentTypeID) error
+	DeleteDraft(context.Context, int64, string) error
+	SubmitDraft(context.Context, *domain.Request) error
+	WithdrawRequest(context.Context, string, string, string) error
+	ClaimRequest(context.Context, string, string, string) ([]domain.Assignment, error)
+	ReleaseRequest(context.Context, string, string, string) error
+	AssignRequest(context.Context, string, string, string, int64) ([]domain.Assignment, error)
+	BreachedRequests(context.Context, domain.SLAStage) ([]domain.Verification, error)
+	GetStats(context.Context, domain.StatsQuery) (*domain.Stats, error)
+	ExportVerifications(context.Context, domain.QueueQuery, func(domain.Verification) error) error
+	ExportValidations(context.Context, domain.QueueQuery, func(domain.Verification) error) error
+	BeginIdempotentRequest(context.Context, *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error)
+	CompleteIdempotentRequest(context.Context, *domain.IdempotencyRecord) error
+	ReleaseIdempotentRequest(context.Context, *domain.IdempotencyRecord) error
+	SubscribeRequestEvents(context.Context, string, string) (<-chan domain.RequestEvent, func(), error)
+	SubscribeUserEvents(context.Context, string) (<-chan domain.RequestEvent, func(), error)
+	SubscribeQueueEvents(context.Context) (<-chan domain.RequestEvent, func(), error)
+	CreateWebhook(context.Context, string, *domain.WebhookSubscription) error
+	GetWebhooks(context.Context) ([]domain.WebhookSubscription, error)
+	GetWebhook(context.Context, int64) (*domain.WebhookSubscription, error)
+	UpdateWebhook(context.Context, *domain.WebhookSubscription) error
+	DeleteWebhook(context.Context, int64) error
+	GetWebhookDeliveries(context.Context, domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error)
+	RedeliverWebhook(context.Context, int64, int64) (*domain.WebhookDelivery, error)
+	BulkVerifyRequests(context.Context, *domain.BulkVerification) ([]domain.BulkResult, error)
+	BulkValidateRequests(context.Context, *domain.BulkValidation) ([]domain.BulkResult, error)
+	CreateUpload(context.Context, string, *domain.UploadSession) error
+	GetUpload(context.Context, string, string) (*domain.UploadSession, error)
+	AppendUpload(context.Context, string, string, int64, []byte) (*domain.UploadSession, error)
+	DeleteUpload(context.Context, string, string) error
 }
 
 type Repository interface {
 	GetSuggestions(context.Context, string, int64, int64) ([]domain.Suggestion, error)
-	CreateRequestByUserID(context.Context, *domain.Request) error
+	CreateRequestByUserID(context.Context, *domain.Request, ...domain.OutboxMessage) error
 	GetAllRequestsByUserID(context.Context, int64) ([]domain.Request, error)
 	GetAllRequestsByCuil(context.Context, string) ([]domain.Request, error)
-	CheckAblOwnership(context.Context, string, int) (bool, error)
-	CreateRequestByCuil(context.Context, *domain.Request) error
-	UpdateRequestWithObservations(context.Context, *domain.VerifiedRequest) (string, string, string, error)
+	CreateRequestByCuil(context.Context, *domain.Request, ...domain.OutboxMessage) error
+	UpdateRequestWithObservations(context.Context, *domain.VerifiedRequest, ...domain.OutboxMessage) (string, string, string, error)
 	GetRequestPersonByCuil(context.Context, string) (*domain.Request, error)
 	GetRequestByFileNumber(context.Context, string) (*domain.Request, error)
 	RequestsVerifications(context.Context, string) (*domain.Verification, error)
-	GetAllRequestsVerifications(context.Context) ([]domain.Verification, error)
-	GetAllRequestsValidations(context.Context) ([]domain.Verification, error)
+	GetAllRequestsVerifications(context.Context, domain.QueueQuery) (*domain.VerificationPage, error)
+	GetAllRequestsValidations(context.Context, domain.QueueQuery) (*domain.VerificationPage, error)
 	GetDocumentsByCode(context.Context, string) ([]domain.Document, error)
+	GetDocumentVersions(ctx context.Context, fileNumber string, docType domain.DocumentTypeID) ([]domain.DocumentVersion, error)
+	GetDocumentVersion(ctx context.Context, fileNumber string, docType domain.DocumentTypeID, version int) (*domain.DocumentVersion, error)
 	GetValidationDocumentsByCode(ctx context.Context, id string) ([]domain.Document, error)
 	GetDocumentByID(context.Context, string) (domain.Document, error)
 	GetRequestByID(ctx context.Context, reqID int64) (*domain.Request, error)
 	UpdateRequest(ctx context.Context, id int64, code string) error
-	UpdateRequestStatus(ctx context.Context, id int64, status int) error
-	UpdateUserRequest(ctx context.Context, req *domain.Request) (string, string, error)
-	ValidateRequest(context.Context, *domain.ValidateRequest) (int64, error)
+	GetRequestFiles(ctx context.Context, requestID int64) ([]domain.DocumentRequest, error)
+	TransitionRequestStatus(ctx context.Context, id int64, from, to domain.RequestStatus, observations string) error
+	GetRequestHistory(ctx context.Context, fileNumber string) (*domain.RequestHistory, error)
+	GetUserAccessByCuil(ctx context.Context, cuil string) (*domain.UserAccess, error)
+	UpdateUserRequest(ctx context.Context, req *domain.Request, msgs ...domain.OutboxMessage) (string, string, error)
+	ValidateRequest(context.Context, *domain.ValidateRequest, ...domain.OutboxMessage) (int64, error)
 	GetInsuranceDocumentByCode(ctx context.Context, id string, docType int) (string, error)
 	GetRequestByExpCode(context.Context, string) (*domain.Request, error)
 	GetReplacementIFDocumentsByCode(ctx context.Context, id string, insurance bool) ([]domain.Document, error)
 	UpdateVerificationStatus(ctx context.Context, fileNumber string) error
 	GetPersonByUserID(ctx context.Context, id int64) (string, error)
-	UpdateRequestStatusByFileNumber(ctx context.Context, fileNumber string, status int) error
+	GetRequestOwner(ctx context.Context, fileNumber string) (int64, int64, error)
+	CreateMessage(ctx context.Context, fileNumber string, msg *domain.Message, msgs ...domain.OutboxMessage) error
+	GetMessageThread(ctx context.Context, fileNumber string, userID int64) (*domain.MessageThread, error)
+	MarkMessagesRead(ctx context.Context, requestID, userID int64) error
+	GetMessageRecipients(ctx context.Context, requestID, authorID int64) ([]string, error)
+	GetRequestTypes(ctx context.Context, activeOnly bool) ([]domain.RequestType, error)
+	GetRequestType(ctx context.Context, id int) (*domain.RequestType, error)
+	GetRequestTypeByFileNumber(ctx context.Context, fileNumber string) (*domain.RequestType, error)
+	CreateDraft(ctx context.Context, draft *domain.Draft) error
+	GetDrafts(ctx context.Context, userID int64) ([]domain.Draft, error)
+	GetDraft(ctx context.Context, id, userID int64) (*domain.Draft, error)
+	GetDraftDocuments(ctx context.Context, id, userID int64) ([]domain.DraftDocument, error)
+	UpdateDraft(ctx context.Context, draft *domain.Draft) error
+	DeleteDraftDocument(ctx context.Context, id, userID int64, docType domain.DocumentTypeID) error
+	DeleteDraft(ctx context.Context, id, userID int64) error
+	SubmitDraft(ctx context.Context, req *domain.Request, msgs ...domain.OutboxMessage) error
+	DeleteStaleDrafts(ctx context.Context, before time.Time) (int64, error)
+	WithdrawRequest(ctx context.Context, withdrawal *domain.Withdrawal, msgs ...domain.OutboxMessage) error
+	ClaimRequest(ctx context.Context, claim *domain.Claim) ([]domain.Assignment, error)
+	ReleaseRequest(ctx context.Context, fileNumber string, tracks []domain.StatusTrack, userID int64) error
+	GetSLAPolicies(ctx context.Context) (domain.SLAPolicies, error)
+	GetHolidays(ctx context.Context) ([]time.Time, error)
+	GetOpenRequests(ctx context.Context) ([]domain.Verification, error)
+	GetStreetAbbreviations(ctx context.Context) ([]domain.StreetAbbreviation, error)
+	GetStreetAliases(ctx context.Context) ([]domain.StreetAlias, error)
+	GetAblOwners(ctx context.Context, ablNumber int64) ([]domain.PropertyOwner, error)
+	GetRequestOwners(ctx context.Context, fileNumber string) ([]domain.PropertyOwner, error)
+	GetOwnershipClaimant(ctx context.Context, cuil string) (*domain.OwnershipClaimant, error)
+	FindOverlappingRequests(ctx context.Context, q domain.DuplicateQuery) ([]domain.RelatedRequest, error)
+	GetRelatedRequests(ctx context.Context, fileNumber string) ([]domain.RelatedRequest, error)
+	BeginIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord, ttl time.Duration) (*domain.IdempotencyRecord, error)
+	CompleteIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord) error
+	ReleaseIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	ListenRequestEvents(ctx context.Context, fn func(domain.RequestEvent)) error
+	CreateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) error
+	GetWebhookSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id int64) (*domain.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, sub *domain.WebhookSubscription) error
+	DeleteWebhookSubscription(ctx context.Context, id int64) error
+	GetWebhookDeliveries(ctx context.Context, q domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, subscriptionID, deliveryID int64) (*domain.WebhookDelivery, error)
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.WebhookDelivery, error)
+	CompleteWebhookDelivery(ctx context.Context, id int64, statusCode int) error
+	FailWebhookDelivery(ctx context.Context, id int64, statusCode int, lastError string, retryIn time.Duration, dead bool) error
+	GetStats(ctx context.Context, q domain.StatsQuery) (*domain.Stats, error)
+	ExportRequestsVerifications(ctx context.Context, q domain.QueueQuery, fn func(domain.Verification) error) error
+	ExportRequestsValidations(ctx context.Context, q domain.QueueQuery, fn func(domain.Verification) error) error
+	ClaimOutboxMessages(ctx context.Context, limit int, lease time.Duration) ([]domain.OutboxMessage, error)
+	CompleteOutboxMessage(ctx context.Context, id int64, followUps ...domain.OutboxMessage) error
+	FailOutboxMessage(ctx context.Context, id int64, lastError string, retryIn time.Duration, dead bool) error
+	CreateUploadSession(ctx context.Context, session *domain.UploadSession) error
+	GetUploadSession(ctx context.Context, id string, userID int64) (*domain.UploadSession, error)
+	UpdateUploadOffset(ctx context.Context, id string, from, to int64, write func() error) error
+	DeleteUploadSessions(ctx context.Context, ids ...string) error
+	GetExpiredUploadSessions(ctx context.Context, limit int) ([]string, error)
+}
+
+// UploadStore guarda los archivos de las subidas reanudables hasta que una solicitud los usa
+type UploadStore interface {
+	// Append escribe el fragmento a partir de offset, descartando lo guardado después de esa posición
+	Append(ctx context.Context, id string, offset int64, data []byte) error
+	Read(ctx context.Context, id string) ([]byte, error)
+	Delete(ctx context.Context, id string) error
 }
 
 type HttpClient interface {
@@ -64,4 +175,33 @@ type HttpClient interface {
 	SendEmailUpdate(ctx context.Context, code, email, observations string) error
 	SendEmailUpdateRequest(ctx context.Context, code, email string) error
 	SendEmailValidateRequest(ctx context.Context, code, email string) error
+	SendEmailNewMessage(ctx context.Context, code, email, content, requestType string) error
+	SendEmailWithdrawn(ctx context.Context, code, email, reason, requestType string) error
+	SendWithdrawalDocument(ctx context.Context, code, content, name string) error
+	SendWebhook(ctx context.Context, url string, headers map[string]string, payload []byte) (int, error)
+}
+
+type OutboxWorker interface {
+	Start(context.Context)
+}
+
+type DraftCleaner interface {
+	Start(context.Context)
+}
+
+type IdempotencyCleaner interface {
+	Start(context.Context)
+}
+
+type UploadCleaner interface {
+	Start(context.Context)
+}
+
+type WebhookWorker interface {
+	Start(context.Context)
+}
+
+type RequestEvents interface {
+	Start(context.Context)
+	Subscribe(domain.RequestEventFilter) (<-chan domain.RequestEvent, func())
 }
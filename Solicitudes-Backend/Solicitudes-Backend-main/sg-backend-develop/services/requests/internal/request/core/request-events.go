@@ -0,0 +1,88 @@
+package request
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+type requestEvents struct {
+	repository  ports.Repository
+	mu          sync.RWMutex
+	subscribers map[*eventSubscriber]struct{}
+}
+
+type eventSubscriber struct {
+	events chan domain.RequestEvent
+	filter domain.RequestEventFilter
+}
+
+func NewRequestEvents(repository ports.Repository) ports.RequestEvents {
+	return &requestEvents{
+		repository:  repository,
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+// Start escucha los cambios de estado de la base y los reparte entre los suscriptores, hasta que se
+// cancele el contexto. Si se pierde la conexión vuelve a escuchar; los cambios de ese intervalo se pierden
+func (e *requestEvents) Start(ctx context.Context) {
+	for {
+		err := e.repository.ListenRequestEvents(ctx, e.publish)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("request events: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(domain.RequestEventsReconnectDelay):
+		}
+	}
+}
+
+// Subscribe devuelve los eventos que cumplen el filtro y la función para dejar de recibirlos
+func (e *requestEvents) Subscribe(filter domain.RequestEventFilter) (<-chan domain.RequestEvent, func()) {
+	sub := &eventSubscriber{
+		events: make(chan domain.RequestEvent, domain.RequestEventsBuffer),
+		filter: filter,
+	}
+
+	e.mu.Lock()
+	e.subscribers[sub] = struct{}{}
+	e.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			e.mu.Lock()
+			delete(e.subscribers, sub)
+			e.mu.Unlock()
+		})
+	}
+
+	return sub.events, cancel
+}
+
+// publish nunca bloquea la escucha: si un suscriptor no consume sus eventos, los nuevos se descartan
+func (e *requestEvents) publish(event domain.RequestEvent) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for sub := range e.subscribers {
+		if sub.filter != nil && !sub.filter(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("request events: subscriber buffer full, event of request %d dropped", event.RequestID)
+		}
+	}
+}
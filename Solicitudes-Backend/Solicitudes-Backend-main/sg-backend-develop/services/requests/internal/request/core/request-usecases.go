@@ -2,9 +2,9 @@ package request
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
@@ -12,14 +12,25 @@ import (
 )
 
 type useCases struct {
-	repository ports.Repository
-	httpClient ports.HttpClient
+	repository  ports.Repository
+	httpClient  ports.HttpClient
+	assignments domain.AssignmentOptions
+	idempotency domain.IdempotencyOptions
+	uploads     domain.UploadOptions
+	events      ports.RequestEvents
+	uploadStore ports.UploadStore
+	gazetteer   gazetteerCache
 }
 
-func NewUseCases(repository ports.Repository, httpClient ports.HttpClient) ports.UseCases {
+func NewUseCases(repository ports.Repository, httpClient ports.HttpClient, events ports.RequestEvents, uploadStore ports.UploadStore, assignments domain.AssignmentOptions, idempotency domain.IdempotencyOptions, uploads domain.UploadOptions) ports.UseCases {
 	return &useCases{
-		repository: repository,
-		httpClient: httpClient,
+		repository:  repository,
+		httpClient:  httpClient,
+		assignments: assignments,
+		idempotency: idempotency,
+		uploads:     uploads,
+		events:      events,
+		uploadStore: uploadStore,
 	}
 }
 
@@ -56,6 +67,14 @@ func (u *useCases) GetSuggestions(ctx context.Context, inputText string) ([]doma
 		return nil, fmt.Errorf("invalid input: all parameters are empty or zero")
 	}
 
+	if partialSuggestion.AddrStreet != "" {
+		gazetteer, err := u.streetGazetteer(ctx)
+		if err != nil {
+			return nil, err
+		}
+		partialSuggestion.AddrStreet = gazetteer.Canonicalize(partialSuggestion.AddrStreet)
+	}
+
 	suggestions, err := u.repository.GetSuggestions(ctx, partialSuggestion.AddrStreet, partialSuggestion.AddrNum, partialSuggestion.AblNum)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get suggestions from repository: %w", err)
@@ -64,13 +83,23 @@ func (u *useCases) GetSuggestions(ctx context.Context, inputText string) ([]doma
 	return suggestions, nil
 }
 
-func (u *useCases) CheckAblOwnership(ctx context.Context, cuil string, ablNum int) (bool, error) {
-	userIsOwner, err := u.repository.CheckAblOwnership(ctx, cuil, ablNum)
+// CheckAblOwnership verifica si la persona con el CUIL es titular de la cuenta de ABL
+func (u *useCases) CheckAblOwnership(ctx context.Context, cuil string, ablNum int) (*domain.OwnershipCheck, error) {
+	claimant, err := u.repository.GetOwnershipClaimant(ctx, cuil)
 	if err != nil {
-		return false, fmt.Errorf("error checking ABL ownership: %w", err)
+		return nil, fmt.Errorf("error checking ABL ownership: %w", err)
+	}
+	if claimant == nil {
+		claimant = &domain.OwnershipClaimant{CUIL: cuil}
 	}
 
-	return userIsOwner, nil
+	owners, err := u.repository.GetAblOwners(ctx, int64(ablNum))
+	if err != nil {
+		return nil, fmt.Errorf("error checking ABL ownership: %w", err)
+	}
+
+	check := domain.MatchOwnership(*claimant, owners)
+	return &check, nil
 }
 
 func (u *useCases) RequestsVerifications(ctx context.Context, cuil string) (*domain.Verification, error) {
@@ -82,22 +111,56 @@ func (u *useCases) RequestsVerifications(ctx context.Context, cuil string) (*dom
 	return verification, nil
 }
 
-func (u *useCases) AllRequestsVerifications(ctx context.Context) ([]domain.Verification, error) {
-	verification, err := u.repository.GetAllRequestsVerifications(ctx)
+func (u *useCases) AllRequestsVerifications(ctx context.Context, query domain.QueueQuery) (*domain.VerificationPage, error) {
+	query.Normalize()
+
+	page, err := u.repository.GetAllRequestsVerifications(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("error checking ABL ownership: %w", err)
+		return nil, fmt.Errorf("error getting all requests: %w", err)
 	}
 
-	return verification, nil
+	if err := u.applySLA(ctx, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
 
-func (u *useCases) AllRequestsValidations(ctx context.Context) ([]domain.Verification, error) {
-	verification, err := u.repository.GetAllRequestsValidations(ctx)
+func (u *useCases) AllRequestsValidations(ctx context.Context, query domain.QueueQuery) (*domain.VerificationPage, error) {
+	query.Normalize()
+
+	page, err := u.repository.GetAllRequestsValidations(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("error getting all requests: %w", err)
 	}
 
-	return verification, nil
+	if err := u.applySLA(ctx, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// ExportVerifications recorre la bandeja de verificación completa, sin paginar, con los filtros de la consulta
+func (u *useCases) ExportVerifications(ctx context.Context, query domain.QueueQuery, fn func(domain.Verification) error) error {
+	query.Normalize()
+
+	if err := u.repository.ExportRequestsVerifications(ctx, query, fn); err != nil {
+		return fmt.Errorf("error exporting requests: %w", err)
+	}
+
+	return nil
+}
+
+// ExportValidations recorre la bandeja de validación completa, sin paginar, con los filtros de la consulta
+func (u *useCases) ExportValidations(ctx context.Context, query domain.QueueQuery, fn func(domain.Verification) error) error {
+	query.Normalize()
+
+	if err := u.repository.ExportRequestsValidations(ctx, query, fn); err != nil {
+		return fmt.Errorf("error exporting requests: %w", err)
+	}
+
+	return nil
 }
 
 func (u *useCases) DocumentsByCode(ctx context.Context, id string) (*domain.Request, []domain.Document, string, error) {
@@ -121,6 +184,23 @@ func (u *useCases) DocumentsByCode(ctx context.Context, id string) (*domain.Requ
 		return request, documents, gedoCode, fmt.Errorf("error getting documents: %w", err)
 	}
 
+	owners, err := u.repository.GetRequestOwners(ctx, id)
+	if err != nil {
+		return request, documents, gedoCode, fmt.Errorf("error getting owners: %w", err)
+	}
+
+	ownership := domain.MatchOwnership(domain.OwnershipClaimant{
+		FirstName: request.FirstName,
+		LastName:  request.LastName,
+		CUIL:      request.Cuil,
+	}, owners)
+	request.Ownership = &ownership
+
+	request.RelatedRequests, err = u.repository.GetRelatedRequests(ctx, id)
+	if err != nil {
+		return request, documents, gedoCode, fmt.Errorf("error getting related requests: %w", err)
+	}
+
 	return request, documents, gedoCode, nil
 }
 
@@ -134,11 +214,32 @@ func (u *useCases) DocumentByID(ctx context.Context, id string) (domain.Document
 }
 
 func (u *useCases) CreateRequestByCuil(ctx context.Context, req *domain.Request) error {
-	user, err := u.repository.GetRequestPersonByCuil(ctx, req.Cuil)
+	uploads, err := u.resolveUploads(ctx, req.Cuil, req.Documents)
 	if err != nil {
 		return err
 	}
 
+	msg, err := u.prepareRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repository.CreateRequestByCuil(ctx, req, msg); err != nil {
+		return err
+	}
+
+	u.releaseUploads(ctx, uploads...)
+	return nil
+}
+
+// prepareRequest completa los datos del ciudadano, valida la solicitud contra su tipo de trámite y arma
+// el mensaje de outbox que genera el expediente. Lo comparten el alta y la presentación de borradores
+func (u *useCases) prepareRequest(ctx context.Context, req *domain.Request) (domain.OutboxMessage, error) {
+	user, err := u.repository.GetRequestPersonByCuil(ctx, req.Cuil)
+	if err != nil {
+		return domain.OutboxMessage{}, err
+	}
+
 	req.Cuil = user.Cuil
 	req.Dni = user.Dni
 	req.FirstName = user.FirstName
@@ -146,113 +247,115 @@ func (u *useCases) CreateRequestByCuil(ctx context.Context, req *domain.Request)
 	req.Email = user.Email
 	req.Phone = user.Phone
 
-	err = u.repository.CreateRequestByCuil(ctx, req)
+	if req.RequestTypeID == 0 {
+		req.RequestTypeID = domain.DefaultRequestTypeID
+	}
+
+	requestType, err := u.repository.GetRequestType(ctx, req.RequestTypeID)
 	if err != nil {
-		return err
+		return domain.OutboxMessage{}, err
 	}
 
-	ctx = context.Background()
-	go func(ctx context.Context, req *domain.Request) {
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Printf("panic in goroutine: %v", r)
-			}
-		}()
+	if err := requestType.Validate(req); err != nil {
+		return domain.OutboxMessage{}, err
+	}
+
+	// Los archivos se validan antes de guardar la solicitud y de enviarlos al file-manager
+	if err := u.uploads.Validate(requestType.UploadTypes(), domain.DocumentUploads(req.Documents)); err != nil {
+		return domain.OutboxMessage{}, err
+	}
+	req.Templates = requestType.Templates
 
-		code, err := u.httpClient.SendCreatedRequest(ctx, req)
+	if requestType.Policy() != domain.DuplicatePolicyAllow {
+		related, err := u.repository.FindOverlappingRequests(ctx, domain.NewDuplicateQuery(req, time.Now()))
 		if err != nil {
-			u.repository.UpdateRequestStatus(ctx, req.ID, 2)
-		} else if err := u.repository.UpdateRequest(ctx, req.ID, code); err != nil {
-			fmt.Println(err.Error())
-		} else {
-			err := u.httpClient.SendEmail(ctx, code, req.Email)
-			if err != nil {
-				fmt.Println(err.Error())
-			}
+			return domain.OutboxMessage{}, err
 		}
-	}(ctx, req)
+		if err := requestType.CheckDuplicates(related); err != nil {
+			return domain.OutboxMessage{}, err
+		}
+		req.RelatedRequests = related
+	}
 
-	return nil
+	// Los archivos se guardan con la solicitud y el worker los lee al crear el expediente, para no
+	// copiarlos en el mensaje de outbox
+	payload := *req
+	payload.Documents = nil
+	payload.RelatedRequests = nil
+
+	return newOutboxMessage(domain.OutboxActionCreateRecord, payload)
 }
 
 func (u *useCases) UpdateRequest(ctx context.Context, req *domain.VerifiedRequest) error {
-	user, err := u.repository.GetRequestPersonByCuil(ctx, req.Cuil)
+	requestType, err := u.repository.GetRequestTypeByFileNumber(ctx, req.FileNumber)
 	if err != nil {
 		return err
 	}
 
-	obsTasks, obsProperty, email, err := u.repository.UpdateRequestWithObservations(ctx, req)
-	if err != nil {
-		return err
+	if !requestType.UsesTrack(domain.StatusTrack(req.VerificationType)) {
+		return fmt.Errorf("%w: %s (%s)", domain.ErrTrackNotUsed, req.VerificationType, requestType.Name)
 	}
 
-	username := fmt.Sprintf("%s %s", user.FirstName, user.LastName)
-
-	ctx = context.Background()
-	go func(ctx context.Context, base64Doc, fileNumber, name, email string) {
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Printf("panic in goroutine: %v", r)
-			}
-		}()
-
-		err := u.httpClient.SendVerificationDocument(ctx, base64Doc, fileNumber, req.Reference, req.VerificationType, name)
+	// Con decisiones por documento el circuito queda observado si se rechazó alguno, y las
+	// observaciones incluyen el motivo de cada rechazo
+	if len(req.Documents) > 0 {
+		documents, err := u.repository.GetDocumentsByCode(ctx, req.FileNumber)
 		if err != nil {
-			fmt.Println(err.Error())
+			return fmt.Errorf("error getting documents: %w", err)
 		}
 
-		if req.Observations != "" {
-			observations := strings.TrimSpace(obsTasks) + "</br>" + strings.TrimSpace(obsProperty)
-			err = u.httpClient.SendEmailUpdate(ctx, fileNumber, email, observations)
-			if err != nil {
-				fmt.Println(err.Error())
-			}
-		} else {
-			if err := u.repository.UpdateRequestStatusByFileNumber(ctx, fileNumber, 1); err != nil {
-				fmt.Println(err.Error())
-			}
+		decided, err := requestType.ApplyDocumentDecisions(domain.StatusTrack(req.VerificationType), documents, req.Documents)
+		if err != nil {
+			return err
 		}
-	}(ctx, req.FinalVerificationDocument, req.FileNumber, username, email)
+		req.Observations = domain.DocumentObservations(req.Observations, decided)
+	}
 
-	return nil
-}
+	user, err := u.repository.GetRequestPersonByCuil(ctx, req.Cuil)
+	if err != nil {
+		return err
+	}
 
-func (u *useCases) ValidateRequest(ctx context.Context, req *domain.ValidateRequest) error {
-	userID, err := u.repository.ValidateRequest(ctx, req)
+	msg, err := newOutboxMessage(domain.OutboxActionSendVerificationDocument, domain.VerificationDocumentPayload{
+		FileNumber:       req.FileNumber,
+		Content:          req.FinalVerificationDocument,
+		Reference:        req.Reference,
+		VerificationType: req.VerificationType,
+		Username:         fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+		HasObservations:  req.Observations != "",
+	})
 	if err != nil {
 		return err
 	}
 
+	if _, _, _, err := u.repository.UpdateRequestWithObservations(ctx, req, msg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (u *useCases) ValidateRequest(ctx context.Context, req *domain.ValidateRequest) error {
+	var msgs []domain.OutboxMessage
 	if req.IsValid {
 		user, err := u.repository.GetRequestPersonByCuil(ctx, req.Cuil)
 		if err != nil {
 			return err
 		}
-		username := fmt.Sprintf("%s %s", user.FirstName, user.LastName)
-
-		ctx = context.Background()
-		go func(ctx context.Context, content, name string) {
-			defer func() {
-				if r := recover(); r != nil {
-					fmt.Printf("panic in goroutine: %v", r)
-				}
-			}()
-
-			err := u.httpClient.SendValidationDocument(ctx, req.FileNumber, content, name)
-			if err != nil {
-				fmt.Println(err.Error())
-			}
 
-			email, err := u.repository.GetPersonByUserID(ctx, userID)
-			if err != nil {
-				fmt.Println(err.Error())
-				return
-			}
-			err = u.httpClient.SendEmailValidateRequest(ctx, req.FileNumber, email)
-			if err != nil {
-				fmt.Println(err.Error())
-			}
-		}(ctx, req.FileContent, username)
+		msg, err := newOutboxMessage(domain.OutboxActionSendValidationDocument, domain.ValidationDocumentPayload{
+			FileNumber: req.FileNumber,
+			Content:    req.FileContent,
+			Username:   fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+		})
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	if _, err := u.repository.ValidateRequest(ctx, req, msgs...); err != nil {
+		return err
 	}
 
 	return nil
@@ -338,11 +441,50 @@ func orderReplacementIFs(documents []domain.Document) []domain.Document {
 }
 
 func (u *useCases) UpdateRequestByFileNumber(ctx context.Context, req *domain.Request) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, req.Cuil)
+	if err != nil {
+		return err
+	}
+
+	_, ownerID, err := u.repository.GetRequestOwner(ctx, req.FileNumber)
+	if err != nil {
+		return err
+	}
+
+	// Solo el ciudadano que presentó la solicitud puede reenviar sus documentos
+	if access.UserID != ownerID {
+		return fmt.Errorf("%w: %s", domain.ErrRequestAccessDenied, req.FileNumber)
+	}
+
 	user, err := u.repository.GetRequestPersonByCuil(ctx, req.Cuil)
 	if err != nil {
 		return err
 	}
 
+	uploads, err := u.resolveUploads(ctx, req.Cuil, req.Documents)
+	if err != nil {
+		return err
+	}
+
+	// Si el verificador rechazó documentos puntuales solo se vuelven a subir esos
+	documents, err := u.repository.GetDocumentsByCode(ctx, req.FileNumber)
+	if err != nil {
+		return fmt.Errorf("error getting documents: %w", err)
+	}
+
+	if err := domain.CheckResubmission(domain.RejectedDocuments(documents), req.Documents); err != nil {
+		return err
+	}
+
+	requestType, err := u.repository.GetRequestTypeByFileNumber(ctx, req.FileNumber)
+	if err != nil {
+		return err
+	}
+
+	if err := u.uploads.Validate(requestType.UploadTypes(), domain.DocumentUploads(req.Documents)); err != nil {
+		return err
+	}
+
 	req.Cuil = user.Cuil
 	req.Dni = user.Dni
 	req.FirstName = user.FirstName
@@ -350,37 +492,629 @@ func (u *useCases) UpdateRequestByFileNumber(ctx context.Context, req *domain.Re
 	req.Email = user.Email
 	req.Phone = user.Phone
 
-	req.Observations, req.ObservationsTasks, err = u.repository.UpdateUserRequest(ctx, req)
+	// Como en el alta, los archivos se guardan con la solicitud y el worker los lee al enviarlos al expediente
+	payload := *req
+	payload.Documents = nil
+
+	msg, err := newOutboxMessage(domain.OutboxActionUpdateRecordDocuments, payload)
 	if err != nil {
 		return err
 	}
 
-	ctx = context.Background()
-	go func(ctx context.Context, req *domain.Request) {
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Printf("panic in goroutine: %v", r)
-			}
-		}()
+	req.Observations, req.ObservationsTasks, err = u.repository.UpdateUserRequest(ctx, req, msg)
+	if err != nil {
+		return err
+	}
+
+	u.releaseUploads(ctx, uploads...)
+	return nil
+}
+
+// GetRejectedDocuments devuelve los documentos que el verificador rechazó y el ciudadano tiene que volver a subir
+func (u *useCases) GetRejectedDocuments(ctx context.Context, fileNumber, cuil string) ([]domain.Document, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ownerID, err := u.repository.GetRequestOwner(ctx, fileNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if !access.CanRead(ownerID) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrRequestAccessDenied, fileNumber)
+	}
+
+	documents, err := u.repository.GetDocumentsByCode(ctx, fileNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting documents: %w", err)
+	}
+
+	return domain.RejectedDocuments(documents), nil
+}
+
+// GetDocumentVersions devuelve las versiones de un tipo de documento del expediente, para que el
+// verificador compare lo que cambió entre reenvíos
+func (u *useCases) GetDocumentVersions(ctx context.Context, fileNumber string, docType domain.DocumentTypeID) ([]domain.DocumentVersion, error) {
+	versions, err := u.repository.GetDocumentVersions(ctx, fileNumber, docType)
+	if err != nil {
+		return nil, fmt.Errorf("error getting document versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+func (u *useCases) GetDocumentVersion(ctx context.Context, fileNumber string, docType domain.DocumentTypeID, version int) (*domain.DocumentVersion, error) {
+	return u.repository.GetDocumentVersion(ctx, fileNumber, docType, version)
+}
+
+// GetRequestHistory devuelve la línea de tiempo completa al personal municipal y una vista
+// filtrada al ciudadano que presentó la solicitud
+func (u *useCases) GetRequestHistory(ctx context.Context, fileNumber, cuil string) (*domain.RequestHistory, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := u.repository.GetRequestHistory(ctx, fileNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting request history: %w", err)
+	}
+
+	if !access.CanRead(history.UserID) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrRequestAccessDenied, fileNumber)
+	}
+
+	if access.HasPermission(domain.PermissionReadAll) {
+		return history, nil
+	}
+
+	return history.CitizenView(), nil
+}
+
+func (u *useCases) GetUserAccess(ctx context.Context, cuil string) (*domain.UserAccess, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// PostMessage agrega un mensaje al hilo de la solicitud y notifica por email al resto de los participantes
+func (u *useCases) PostMessage(ctx context.Context, fileNumber, cuil string, msg *domain.Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	if err := u.uploads.Validate(domain.AllowedUploadTypes, msg.Uploads()); err != nil {
+		return err
+	}
+
+	access, requestID, err := u.messageAccess(ctx, fileNumber, cuil)
+	if err != nil {
+		return err
+	}
+
+	msg.RequestID = requestID
+	msg.UserID = access.UserID
 
-		err := u.httpClient.SendUpdateRequest(ctx, req)
+	recipients, err := u.repository.GetMessageRecipients(ctx, requestID, access.UserID)
+	if err != nil {
+		return err
+	}
+
+	requestType, err := u.repository.GetRequestTypeByFileNumber(ctx, fileNumber)
+	if err != nil {
+		return err
+	}
+
+	msgs := make([]domain.OutboxMessage, 0, len(recipients))
+	for _, email := range recipients {
+		outboxMsg, err := newOutboxMessage(domain.OutboxActionSendMessageEmail, domain.EmailPayload{
+			FileNumber:   fileNumber,
+			Email:        email,
+			Observations: msg.Content,
+			RequestType:  requestType.Name,
+		})
 		if err != nil {
-			if err := u.repository.UpdateRequestStatus(ctx, req.ID, 7); err != nil {
-				fmt.Println(err.Error())
-				return
-			}
-		} else {
-			err := u.repository.UpdateVerificationStatus(ctx, req.FileNumber)
-			if err != nil {
-				fmt.Println(err.Error())
-			}
+			return err
+		}
+		msgs = append(msgs, outboxMsg)
+	}
 
-			err = u.httpClient.SendEmailUpdateRequest(ctx, req.FileNumber, req.Email)
-			if err != nil {
-				fmt.Println(err.Error())
-			}
+	return u.repository.CreateMessage(ctx, fileNumber, msg, msgs...)
+}
+
+// GetMessageThread devuelve el hilo de mensajes de la solicitud. El ciudadano no ve los nombres del personal municipal
+func (u *useCases) GetMessageThread(ctx context.Context, fileNumber, cuil string) (*domain.MessageThread, error) {
+	access, _, err := u.messageAccess(ctx, fileNumber, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	thread, err := u.repository.GetMessageThread(ctx, fileNumber, access.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting messages: %w", err)
+	}
+
+	if access.HasPermission(domain.PermissionReadAll) {
+		return thread, nil
+	}
+
+	return thread.CitizenView(), nil
+}
+
+func (u *useCases) MarkMessagesRead(ctx context.Context, fileNumber, cuil string) error {
+	access, requestID, err := u.messageAccess(ctx, fileNumber, cuil)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.MarkMessagesRead(ctx, requestID, access.UserID)
+}
+
+// messageAccess obtiene el usuario y verifica que pueda participar del hilo de la solicitud
+func (u *useCases) messageAccess(ctx context.Context, fileNumber, cuil string) (*domain.UserAccess, int64, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	requestID, ownerID, err := u.repository.GetRequestOwner(ctx, fileNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !access.CanRead(ownerID) {
+		return nil, 0, fmt.Errorf("%w: %s", domain.ErrRequestAccessDenied, fileNumber)
+	}
+
+	return access, requestID, nil
+}
+
+// GetRequestTypes devuelve los tipos de trámite habilitados junto con su formulario y documentos requeridos
+func (u *useCases) GetRequestTypes(ctx context.Context) ([]domain.RequestType, error) {
+	requestTypes, err := u.repository.GetRequestTypes(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting request types: %w", err)
+	}
+
+	return requestTypes, nil
+}
+
+// CreateDraft guarda un borrador de solicitud del ciudadano, con los campos y archivos cargados hasta el momento
+func (u *useCases) CreateDraft(ctx context.Context, cuil string, draft *domain.Draft) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	if err := u.validateDraftDocuments(ctx, draft.Documents); err != nil {
+		return err
+	}
+
+	draft.UserID = access.UserID
+
+	return u.repository.CreateDraft(ctx, draft)
+}
+
+func (u *useCases) GetDrafts(ctx context.Context, cuil string) ([]domain.Draft, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	drafts, err := u.repository.GetDrafts(ctx, access.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting drafts: %w", err)
+	}
+
+	return drafts, nil
+}
+
+func (u *useCases) GetDraft(ctx context.Context, id int64, cuil string) (*domain.Draft, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repository.GetDraft(ctx, id, access.UserID)
+}
+
+// UpdateDraft actualiza parcialmente el borrador: los campos recibidos reemplazan a los guardados y
+// cada archivo reemplaza al anterior del mismo tipo de documento
+func (u *useCases) UpdateDraft(ctx context.Context, cuil string, draft *domain.Draft) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	if err := u.validateDraftDocuments(ctx, draft.Documents); err != nil {
+		return err
+	}
+
+	draft.UserID = access.UserID
+
+	return u.repository.UpdateDraft(ctx, draft)
+}
+
+func (u *useCases) DeleteDraftDocument(ctx context.Context, id int64, cuil string, docType domain.DocumentTypeID) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.DeleteDraftDocument(ctx, id, access.UserID, docType)
+}
+
+func (u *useCases) DeleteDraft(ctx context.Context, id int64, cuil string) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.DeleteDraft(ctx, id, access.UserID)
+}
+
+// SubmitDraft presenta el borrador req.ID con los archivos guardados. Sigue el mismo camino que
+// CreateRequestByCuil: se valida contra el tipo de trámite y se genera el expediente a través del outbox
+func (u *useCases) SubmitDraft(ctx context.Context, req *domain.Request) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, req.Cuil)
+	if err != nil {
+		return err
+	}
+
+	documents, err := u.repository.GetDraftDocuments(ctx, req.ID, access.UserID)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = access.UserID
+	req.Documents = make([]domain.DocumentRequest, 0, len(documents))
+	for _, doc := range documents {
+		req.Documents = append(req.Documents, domain.DocumentRequest{
+			Name:    doc.Name,
+			Type:    doc.Type,
+			Content: doc.Content,
+		})
+	}
+
+	msg, err := u.prepareRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.SubmitDraft(ctx, req, msg)
+}
+
+// WithdrawRequest da de baja la solicitud a pedido del ciudadano que la presentó. Se incorpora un IF con
+// el desistimiento al expediente y se le notifica por email
+func (u *useCases) WithdrawRequest(ctx context.Context, fileNumber, cuil, reason string) error {
+	withdrawal := &domain.Withdrawal{
+		FileNumber: fileNumber,
+		Reason:     reason,
+		Date:       time.Now(),
+	}
+
+	if err := withdrawal.Validate(); err != nil {
+		return err
+	}
+
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	_, ownerID, err := u.repository.GetRequestOwner(ctx, fileNumber)
+	if err != nil {
+		return err
+	}
+
+	// Solo el ciudadano que presentó la solicitud puede desistir de ella
+	if access.UserID != ownerID {
+		return fmt.Errorf("%w: %s", domain.ErrRequestAccessDenied, fileNumber)
+	}
+
+	user, err := u.repository.GetRequestPersonByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	requestType, err := u.repository.GetRequestTypeByFileNumber(ctx, fileNumber)
+	if err != nil {
+		return err
+	}
+
+	withdrawal.UserID = access.UserID
+	withdrawal.Cuil = user.Cuil
+	withdrawal.FullName = fmt.Sprintf("%s %s", user.FirstName, user.LastName)
+	withdrawal.RequestType = requestType.Name
+
+	document, err := newOutboxMessage(domain.OutboxActionSendWithdrawalDocument, domain.WithdrawalDocumentPayload{
+		FileNumber: fileNumber,
+		Content:    base64.StdEncoding.EncodeToString([]byte(withdrawal.DocumentText())),
+		Username:   withdrawal.FullName,
+	})
+	if err != nil {
+		return err
+	}
+
+	email, err := newOutboxMessage(domain.OutboxActionSendWithdrawnEmail, domain.EmailPayload{
+		FileNumber:   fileNumber,
+		Email:        user.Email,
+		Observations: withdrawal.Reason,
+		RequestType:  requestType.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.repository.WithdrawRequest(ctx, withdrawal, document, email)
+}
+
+// ClaimRequest asigna al verificador el circuito indicado de la solicitud, o todos los circuitos
+// que utiliza su tipo si no se indica ninguno. Volver a tomar una solicitud propia renueva la asignación
+func (u *useCases) ClaimRequest(ctx context.Context, fileNumber, cuil, track string) ([]domain.Assignment, error) {
+	tracks, err := u.assignmentTracks(ctx, fileNumber, track)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repository.ClaimRequest(ctx, &domain.Claim{
+		FileNumber: fileNumber,
+		Tracks:     tracks,
+		UserID:     access.UserID,
+		AssignedBy: access.UserID,
+		Lease:      u.assignments.Lease,
+	})
+}
+
+// ReleaseRequest libera las asignaciones del verificador sobre la solicitud
+func (u *useCases) ReleaseRequest(ctx context.Context, fileNumber, cuil, track string) error {
+	tracks, err := u.assignmentTracks(ctx, fileNumber, track)
+	if err != nil {
+		return err
+	}
+
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.ReleaseRequest(ctx, fileNumber, tracks, access.UserID)
+}
+
+// AssignRequest reasigna los circuitos de la solicitud a otro verificador, aunque estén asignados.
+// La utilizan los supervisores para redistribuir el trabajo
+func (u *useCases) AssignRequest(ctx context.Context, fileNumber, cuil, track string, userID int64) ([]domain.Assignment, error) {
+	tracks, err := u.assignmentTracks(ctx, fileNumber, track)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repository.ClaimRequest(ctx, &domain.Claim{
+		FileNumber: fileNumber,
+		Tracks:     tracks,
+		UserID:     userID,
+		AssignedBy: access.UserID,
+		Lease:      u.assignments.Lease,
+		Force:      true,
+	})
+}
+
+// assignmentTracks devuelve los circuitos a asignar: el indicado, si el tipo de solicitud lo utiliza,
+// o todos los del tipo de solicitud
+func (u *useCases) assignmentTracks(ctx context.Context, fileNumber, track string) ([]domain.StatusTrack, error) {
+	requestType, err := u.repository.GetRequestTypeByFileNumber(ctx, fileNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if track == "" {
+		return requestType.Tracks, nil
+	}
+
+	if !requestType.UsesTrack(domain.StatusTrack(track)) {
+		return nil, fmt.Errorf("%w: %s (%s)", domain.ErrTrackNotUsed, track, requestType.Name)
+	}
+
+	return []domain.StatusTrack{domain.StatusTrack(track)}, nil
+}
+
+// BreachedRequests lista las solicitudes que superaron el plazo de la etapa en que se encuentran,
+// de la más atrasada a la menos atrasada. Con stage solo se consideran las solicitudes en esa etapa
+func (u *useCases) BreachedRequests(ctx context.Context, stage domain.SLAStage) ([]domain.Verification, error) {
+	requests, err := u.repository.GetOpenRequests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applySLA(ctx, requests); err != nil {
+		return nil, err
+	}
+
+	breached := make([]domain.Verification, 0)
+	for _, req := range requests {
+		if req.SLA == nil || req.SLA.State != domain.SLAStateOverdue {
+			continue
 		}
-	}(ctx, req)
+		if stage != "" && req.SLA.Stage != stage {
+			continue
+		}
+		breached = append(breached, req)
+	}
+
+	sort.SliceStable(breached, func(i, j int) bool {
+		return breached[i].SLA.DueDate.Before(breached[j].SLA.DueDate)
+	})
+
+	return breached, nil
+}
+
+// applySLA calcula el vencimiento de la etapa en que se encuentra cada solicitud, según los
+// plazos de su tipo de trámite y el calendario de días hábiles
+func (u *useCases) applySLA(ctx context.Context, requests []domain.Verification) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	policies, err := u.repository.GetSLAPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	holidays, err := u.repository.GetHolidays(ctx)
+	if err != nil {
+		return err
+	}
+
+	calendar := domain.NewBusinessCalendar(holidays)
+	now := time.Now()
+	for i := range requests {
+		stage, ok := domain.SLAStageOf(requests[i].Statuses)
+		if !ok {
+			continue
+		}
+
+		policy, ok := policies.For(requests[i].RequestTypeID, stage)
+		if !ok {
+			continue
+		}
+
+		sla := calendar.Evaluate(policy, requests[i].Milestones.StageStart(stage), now)
+		requests[i].SLA = &sla
+	}
 
 	return nil
 }
+
+// GetStats obtiene los indicadores del backoffice para el rango de fechas
+func (u *useCases) GetStats(ctx context.Context, query domain.StatsQuery) (*domain.Stats, error) {
+	stats, err := u.repository.GetStats(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error getting stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// BeginIdempotentRequest reserva la clave de idempotencia de la solicitud. Si la clave ya se usó devuelve
+// la respuesta guardada, que se debe repetir en lugar de procesar la solicitud otra vez
+func (u *useCases) BeginIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.repository.BeginIdempotentRequest(ctx, record, u.idempotency.TTL)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	if err := existing.Replay(record.Fingerprint); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// CompleteIdempotentRequest guarda la respuesta de la solicitud para los reintentos
+func (u *useCases) CompleteIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return u.repository.CompleteIdempotentRequest(ctx, record)
+}
+
+// ReleaseIdempotentRequest libera la clave de una solicitud que falló
+func (u *useCases) ReleaseIdempotentRequest(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return u.repository.ReleaseIdempotentRequest(ctx, record)
+}
+
+// SubscribeRequestEvents suscribe a los cambios de estado de la solicitud del expediente. El ciudadano que
+// la presentó recibe solo los cambios que ve en el historial
+func (u *useCases) SubscribeRequestEvents(ctx context.Context, fileNumber, cuil string) (<-chan domain.RequestEvent, func(), error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestID, ownerID, err := u.repository.GetRequestOwner(ctx, fileNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !access.CanRead(ownerID) {
+		return nil, nil, fmt.Errorf("%w: %s", domain.ErrRequestAccessDenied, fileNumber)
+	}
+
+	staff := access.HasPermission(domain.PermissionReadAll)
+	events, cancel := u.events.Subscribe(func(event domain.RequestEvent) bool {
+		return event.RequestID == requestID && (staff || event.VisibleToCitizen())
+	})
+
+	return events, cancel, nil
+}
+
+// SubscribeUserEvents suscribe a los cambios de estado de las solicitudes presentadas por el usuario,
+// incluida la asignación del número de expediente
+func (u *useCases) SubscribeUserEvents(ctx context.Context, cuil string) (<-chan domain.RequestEvent, func(), error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events, cancel := u.events.Subscribe(func(event domain.RequestEvent) bool {
+		return event.OwnerID == access.UserID && event.VisibleToCitizen()
+	})
+
+	return events, cancel, nil
+}
+
+// SubscribeQueueEvents suscribe a los cambios de estado de todas las solicitudes, para el personal municipal
+func (u *useCases) SubscribeQueueEvents(_ context.Context) (<-chan domain.RequestEvent, func(), error) {
+	events, cancel := u.events.Subscribe(nil)
+	return events, cancel, nil
+}
+
+// BulkVerifyRequests aplica la misma decisión sobre un circuito de verificación a varias solicitudes. Cada
+// solicitud se verifica en su propia transacción, de a una, y el resultado se informa por solicitud; los
+// documentos y emails quedan en el outbox
+func (u *useCases) BulkVerifyRequests(ctx context.Context, bulk *domain.BulkVerification) ([]domain.BulkResult, error) {
+	fileNumbers, err := domain.NormalizeFileNumbers(bulk.FileNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyBulk(ctx, fileNumbers, func(fileNumber string) error {
+		req, err := bulk.Request(fileNumber)
+		if err != nil {
+			return err
+		}
+		return u.UpdateRequest(ctx, req)
+	}), nil
+}
+
+// BulkValidateRequests valida o rechaza varias solicitudes, de a una, informando el resultado por solicitud
+func (u *useCases) BulkValidateRequests(ctx context.Context, bulk *domain.BulkValidation) ([]domain.BulkResult, error) {
+	fileNumbers, err := domain.NormalizeFileNumbers(bulk.FileNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyBulk(ctx, fileNumbers, func(fileNumber string) error {
+		req, err := bulk.Request(fileNumber)
+		if err != nil {
+			return err
+		}
+		return u.ValidateRequest(ctx, req)
+	}), nil
+}
@@ -0,0 +1,57 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// gazetteerCache mantiene en memoria el nomenclador de calles y lo recarga cada domain.GazetteerRefreshInterval
+type gazetteerCache struct {
+	mu       sync.Mutex
+	value    *domain.StreetGazetteer
+	loadedAt time.Time
+}
+
+// streetGazetteer devuelve el nomenclador de calles en caché. Si no se puede recargar se sigue usando
+// la versión anterior, y sólo se devuelve error si nunca se pudo cargar
+func (u *useCases) streetGazetteer(ctx context.Context) (*domain.StreetGazetteer, error) {
+	u.gazetteer.mu.Lock()
+	defer u.gazetteer.mu.Unlock()
+
+	if u.gazetteer.value != nil && time.Since(u.gazetteer.loadedAt) < domain.GazetteerRefreshInterval {
+		return u.gazetteer.value, nil
+	}
+
+	gazetteer, err := u.loadStreetGazetteer(ctx)
+	if err != nil {
+		if u.gazetteer.value != nil {
+			// Se posterga el próximo intento para no consultar la base en cada búsqueda
+			u.gazetteer.loadedAt = time.Now()
+			return u.gazetteer.value, nil
+		}
+		return nil, err
+	}
+
+	u.gazetteer.value = gazetteer
+	u.gazetteer.loadedAt = time.Now()
+
+	return gazetteer, nil
+}
+
+func (u *useCases) loadStreetGazetteer(ctx context.Context) (*domain.StreetGazetteer, error) {
+	abbreviations, err := u.repository.GetStreetAbbreviations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting street abbreviations: %w", err)
+	}
+
+	aliases, err := u.repository.GetStreetAliases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting street aliases: %w", err)
+	}
+
+	return domain.NewStreetGazetteer(abbreviations, aliases), nil
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+// uploadCleanupBatch es la cantidad de subidas vencidas que se eliminan por consulta
+const uploadCleanupBatch = 100
+
+type uploadCleaner struct {
+	repository ports.Repository
+	store      ports.UploadStore
+	options    domain.UploadOptions
+}
+
+func NewUploadCleaner(repository ports.Repository, store ports.UploadStore, options domain.UploadOptions) ports.UploadCleaner {
+	return &uploadCleaner{
+		repository: repository,
+		store:      store,
+		options:    options,
+	}
+}
+
+// Start elimina periódicamente las subidas vencidas que no se usaron en una solicitud, junto con sus
+// archivos, hasta que se cancele el contexto
+func (c *uploadCleaner) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.options.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		c.clean(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *uploadCleaner) clean(ctx context.Context) {
+	deleted := 0
+	for ctx.Err() == nil {
+		ids, err := c.repository.GetExpiredUploadSessions(ctx, uploadCleanupBatch)
+		if err != nil {
+			log.Printf("uploads: %v", err)
+			break
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		// Se eliminan primero los archivos: si falla, la subida sigue registrada y se reintenta
+		removed := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if err := c.store.Delete(ctx, id); err != nil {
+				log.Printf("uploads: %v", err)
+				continue
+			}
+			removed = append(removed, id)
+		}
+
+		if err := c.repository.DeleteUploadSessions(ctx, removed...); err != nil {
+			log.Printf("uploads: %v", err)
+			break
+		}
+		deleted += len(removed)
+
+		if len(removed) < len(ids) || len(ids) < uploadCleanupBatch {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		log.Printf("uploads: %d expired uploads deleted", deleted)
+	}
+}
@@ -0,0 +1,199 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// CreateUpload registra una subida reanudable del ciudadano. Los fragmentos se envían después con AppendUpload
+func (u *useCases) CreateUpload(ctx context.Context, cuil string, session *domain.UploadSession) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := u.uploadTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := session.Validate(u.uploads, allowed); err != nil {
+		return err
+	}
+
+	session.ID, err = domain.NewUploadID()
+	if err != nil {
+		return err
+	}
+	session.UserID = access.UserID
+	session.Offset = 0
+	session.ExpiresAt = time.Now().Add(u.uploads.TTL)
+
+	return u.repository.CreateUploadSession(ctx, session)
+}
+
+// GetUpload devuelve el estado de la subida para que el cliente la retome desde el último byte recibido
+func (u *useCases) GetUpload(ctx context.Context, id, cuil string) (*domain.UploadSession, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.getUploadSession(ctx, id, access.UserID)
+}
+
+// AppendUpload guarda un fragmento de la subida. Al recibir el último se valida el archivo completo con
+// las mismas reglas que los archivos enviados en el cuerpo de la solicitud; si no las cumple se descarta
+func (u *useCases) AppendUpload(ctx context.Context, id, cuil string, offset int64, data []byte) (*domain.UploadSession, error) {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.getUploadSession(ctx, id, access.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := session.CheckChunk(offset, int64(len(data))); err != nil {
+		return session, err
+	}
+
+	// El fragmento se escribe con el offset tomado: si otro pedido avanzó la subida mientras tanto, el offset
+	// guardado ya no coincide y no se toca lo que ese pedido escribió
+	newOffset := offset + int64(len(data))
+	err = u.repository.UpdateUploadOffset(ctx, session.ID, offset, newOffset, func() error {
+		if err := u.uploadStore.Append(ctx, session.ID, offset, data); err != nil {
+			return fmt.Errorf("error storing upload chunk: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return session, err
+	}
+	session.Offset = newOffset
+
+	if !session.Completed() {
+		return session, nil
+	}
+
+	content, err := u.uploadStore.Read(ctx, session.ID)
+	if err != nil {
+		return session, fmt.Errorf("error reading upload: %w", err)
+	}
+
+	allowed, err := u.uploadTypes(ctx)
+	if err != nil {
+		return session, err
+	}
+
+	if err := session.ValidateContent(u.uploads, allowed, content); err != nil {
+		u.releaseUploads(ctx, session.ID)
+		return session, err
+	}
+
+	return session, nil
+}
+
+// DeleteUpload descarta la subida y los fragmentos recibidos
+func (u *useCases) DeleteUpload(ctx context.Context, id, cuil string) error {
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+
+	session, err := u.getUploadSession(ctx, id, access.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.uploadStore.Delete(ctx, session.ID); err != nil {
+		return fmt.Errorf("error deleting upload: %w", err)
+	}
+
+	return u.repository.DeleteUploadSessions(ctx, session.ID)
+}
+
+// getUploadSession obtiene la subida del usuario. Una subida vencida se trata como inexistente aunque
+// todavía no la haya eliminado la limpieza
+func (u *useCases) getUploadSession(ctx context.Context, id string, userID int64) (*domain.UploadSession, error) {
+	session, err := u.repository.GetUploadSession(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Expired(time.Now()) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUploadNotFound, id)
+	}
+
+	return session, nil
+}
+
+// resolveUploads completa el contenido de los documentos que refieren a una subida reanudable en lugar de
+// enviarlo en el cuerpo. Devuelve las subidas usadas, que se eliminan una vez guardada la solicitud
+func (u *useCases) resolveUploads(ctx context.Context, cuil string, documents []domain.DocumentRequest) ([]string, error) {
+	var userID int64
+	var used []string
+	var errs domain.UploadErrors
+
+	for i := range documents {
+		doc := &documents[i]
+		if doc.UploadID == "" {
+			continue
+		}
+
+		if userID == 0 {
+			access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+			if err != nil {
+				return nil, err
+			}
+			userID = access.UserID
+		}
+
+		session, err := u.repository.GetUploadSession(ctx, doc.UploadID, userID)
+		if err != nil && !errors.Is(err, domain.ErrUploadNotFound) {
+			return nil, err
+		}
+
+		if reason := doc.CheckUpload(session, time.Now()); reason != "" {
+			errs = append(errs, domain.UploadError{Index: i, Type: doc.Type, Reason: reason})
+			continue
+		}
+
+		data, err := u.uploadStore.Read(ctx, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading upload: %w", err)
+		}
+
+		doc.AttachUpload(session, data)
+		used = append(used, session.ID)
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return used, nil
+}
+
+// releaseUploads elimina las subidas que ya no se necesitan. Primero se eliminan los archivos; si algo
+// falla, la limpieza las elimina al vencer
+func (u *useCases) releaseUploads(ctx context.Context, ids ...string) {
+	removed := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if err := u.uploadStore.Delete(ctx, id); err != nil {
+			log.Printf("uploads: %v", err)
+			continue
+		}
+		removed = append(removed, id)
+	}
+
+	if err := u.repository.DeleteUploadSessions(ctx, removed...); err != nil {
+		log.Printf("uploads: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"context"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+)
+
+// CreateWebhook registra la suscripción de otro sistema. Si no trae secreto se genera uno, que solo se
+// devuelve en esta respuesta
+func (u *useCases) CreateWebhook(ctx context.Context, cuil string, sub *domain.WebhookSubscription) error {
+	if sub.Secret == "" {
+		secret, err := domain.NewWebhookSecret()
+		if err != nil {
+			return err
+		}
+		sub.Secret = secret
+	}
+
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+
+	access, err := u.repository.GetUserAccessByCuil(ctx, cuil)
+	if err != nil {
+		return err
+	}
+	sub.CreatedBy = access.UserID
+
+	return u.repository.CreateWebhookSubscription(ctx, sub)
+}
+
+func (u *useCases) GetWebhooks(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return u.repository.GetWebhookSubscriptions(ctx)
+}
+
+func (u *useCases) GetWebhook(ctx context.Context, id int64) (*domain.WebhookSubscription, error) {
+	return u.repository.GetWebhookSubscription(ctx, id)
+}
+
+// UpdateWebhook reemplaza la configuración de la suscripción. Sin secreto se conserva el actual
+func (u *useCases) UpdateWebhook(ctx context.Context, sub *domain.WebhookSubscription) error {
+	current, err := u.repository.GetWebhookSubscription(ctx, sub.ID)
+	if err != nil {
+		return err
+	}
+
+	if sub.Secret == "" {
+		sub.Secret = current.Secret
+	}
+	sub.CreatedBy = current.CreatedBy
+	sub.CreatedAt = current.CreatedAt
+
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+
+	return u.repository.UpdateWebhookSubscription(ctx, sub)
+}
+
+func (u *useCases) DeleteWebhook(ctx context.Context, id int64) error {
+	return u.repository.DeleteWebhookSubscription(ctx, id)
+}
+
+// GetWebhookDeliveries devuelve el registro de envíos de la suscripción
+func (u *useCases) GetWebhookDeliveries(ctx context.Context, query domain.WebhookDeliveryQuery) ([]domain.WebhookDelivery, error) {
+	if err := query.Normalize(); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.repository.GetWebhookSubscription(ctx, query.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	return u.repository.GetWebhookDeliveries(ctx, query)
+}
+
+// RedeliverWebhook vuelve a enviar un evento ya enviado, por ejemplo cuando el suscriptor estuvo caído
+// más tiempo que el de los reintentos
+func (u *useCases) RedeliverWebhook(ctx context.Context, subscriptionID, deliveryID int64) (*domain.WebhookDelivery, error) {
+	return u.repository.RedeliverWebhook(ctx, subscriptionID, deliveryID)
+}
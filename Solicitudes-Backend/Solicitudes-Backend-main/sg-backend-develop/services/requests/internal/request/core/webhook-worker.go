@@ -0,0 +1,103 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/domain"
+	"github.com/teamcubation/sg-backend/services/requests/internal/request/core/ports"
+)
+
+type webhookWorker struct {
+	repository ports.Repository
+	httpClient ports.HttpClient
+	options    domain.WebhookOptions
+}
+
+func NewWebhookWorker(repository ports.Repository, httpClient ports.HttpClient, options domain.WebhookOptions) ports.WebhookWorker {
+	return &webhookWorker{
+		repository: repository,
+		httpClient: httpClient,
+		options:    options,
+	}
+}
+
+// Start entrega los webhooks pendientes hasta que se cancele el contexto
+func (w *webhookWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.processBatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *webhookWorker) processBatch(ctx context.Context) {
+	deliveries, err := w.repository.ClaimWebhookDeliveries(ctx, w.options.BatchSize, w.options.Lease)
+	if err != nil {
+		log.Printf("webhooks: %v", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		w.process(ctx, delivery)
+	}
+}
+
+func (w *webhookWorker) process(ctx context.Context, delivery domain.WebhookDelivery) {
+	statusCode, err := w.deliver(ctx, delivery)
+	if err == nil {
+		if err := w.repository.CompleteWebhookDelivery(ctx, delivery.ID, statusCode); err != nil {
+			log.Printf("webhooks: %v", err)
+		}
+		return
+	}
+
+	dead := delivery.Attempts >= w.options.MaxAttempts
+	log.Printf("webhooks: delivery %d (%s) attempt %d failed: %v", delivery.ID, delivery.EventType, delivery.Attempts, err)
+
+	if err := w.repository.FailWebhookDelivery(ctx, delivery.ID, statusCode, err.Error(), w.backoff(delivery.Attempts), dead); err != nil {
+		log.Printf("webhooks: %v", err)
+	}
+}
+
+// deliver firma el contenido en cada intento, para que la marca de tiempo de la firma sea la del envío
+func (w *webhookWorker) deliver(ctx context.Context, delivery domain.WebhookDelivery) (statusCode int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic delivering webhook: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, w.options.Timeout)
+	defer cancel()
+
+	headers := map[string]string{
+		domain.WebhookEventHeader:     string(delivery.EventType),
+		domain.WebhookDeliveryHeader:  strconv.FormatInt(delivery.ID, 10),
+		domain.WebhookSignatureHeader: domain.SignWebhook(delivery.Secret, time.Now(), delivery.Payload),
+	}
+
+	return w.httpClient.SendWebhook(ctx, delivery.URL, headers, delivery.Payload)
+}
+
+func (w *webhookWorker) backoff(attempts int) time.Duration {
+	delay := w.options.BaseBackoff
+	for i := 1; i < attempts && delay < w.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > w.options.MaxBackoff {
+		return w.options.MaxBackoff
+	}
+	return delay
+}